@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/imageexport"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd exports the rainbow art as an image
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the rainbow cost art as an SVG, PNG or animated GIF",
+	Long: `Export the current cost as rainbow art into an image, for badges and
+social images. The format follows the extension of --out: .svg, .png or .gif.
+GIFs are animated at the configured animation speed for --duration.
+No terminal is needed.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+// Export flag variables
+var (
+	exportOut        string
+	exportSize       string
+	exportScale      int
+	exportBackground string
+	exportDuration   time.Duration
+)
+
+// exportMargin is the blank space kept around the art when no canvas size is given, in cells
+const (
+	exportMarginColumns = 2
+	exportMarginRows    = 1
+)
+
+func init() {
+	defaults := imageexport.DefaultOptions()
+	exportCmd.Flags().StringVar(&exportOut, "out", "ccugorg.svg", "Output file (.svg, .png or .gif)")
+	exportCmd.Flags().StringVar(&exportSize, "size", "", "Canvas size as COLUMNSxROWS, trimmed to the art when empty")
+	exportCmd.Flags().IntVar(&exportScale, "scale", defaults.Scale, "Pixels per font pixel")
+	exportCmd.Flags().StringVar(&exportBackground, "background", defaults.Background, "Background color")
+	exportCmd.Flags().DurationVar(&exportDuration, "duration", 2*time.Second, "Length of GIF animations")
+
+	// Appearance flags shared with the root command
+	exportCmd.Flags().StringVar(&animationPattern, "animation-pattern", "", "Animation pattern (rainbow, gradient, pulse, wave)")
+	exportCmd.Flags().StringVar(&animationSpeed, "animation-speed", "", "Animation speed (e.g., 100ms)")
+	exportCmd.Flags().StringVar(&theme, "theme", "", "Color theme (see 'ccugorg themes')")
+	exportCmd.Flags().StringVar(&currency, "currency", "", "Display currency (e.g., USD, EUR, JPY, GBP)")
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+// runExport renders the rainbow art for the current cost and writes it as an image
+func runExport(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(exportOut), "."))
+	if format != "svg" && format != "png" && format != "gif" {
+		return fmt.Errorf("unsupported image format '%s'. Use an .svg, .png or .gif file", filepath.Ext(exportOut))
+	}
+
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	displayConfig := configManager.GetDisplayConfig()
+	if exportSize != "" {
+		if displayConfig.Size.Width, displayConfig.Size.Height, err = parseCanvasSize(exportSize); err != nil {
+			return err
+		}
+	}
+
+	options := imageexport.Options{
+		Scale:      exportScale,
+		Background: exportBackground,
+		Delay:      configManager.GetAnimationConfig().Speed,
+	}
+
+	ctx := context.Background()
+	registry, err := setupPlugins(configManager, false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := registry.ShutdownAll(); err != nil {
+			log.Printf("Warning: Error during plugin shutdown: %v", err)
+		}
+	}()
+
+	cost, err := fetchCost(ctx, registry, configManager)
+	if err != nil {
+		return err
+	}
+
+	// GIFs get one frame per animation step, still images only the first
+	frameCount := 1
+	if format == "gif" && configManager.GetAnimationConfig().Enabled {
+		frameCount = max(1, int(exportDuration/options.Delay))
+	}
+
+	grids, err := renderArtFrames(ctx, registry, configManager, cost, displayConfig, frameCount)
+	if err != nil {
+		return err
+	}
+
+	if err := writeImage(exportOut, format, grids, options); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %s\n", exportOut)
+	return nil
+}
+
+// writeImage encodes the grids to the image file at path, removing the file when
+// encoding or closing it fails so that no partial image is left behind
+func writeImage(path, format string, grids []*domain.ArtGrid, options imageexport.Options) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create image: %w", err)
+	}
+
+	switch format {
+	case "svg":
+		err = imageexport.WriteSVG(file, grids[0], options)
+	case "png":
+		err = imageexport.WritePNG(file, grids[0], options)
+	case "gif":
+		err = imageexport.WriteGIF(file, grids, options)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write image: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+// fetchCost fetches the cost from the data source chain in the display currency
+func fetchCost(ctx context.Context, registry *core.PluginRegistry, configManager *core.ConfigManager) (*domain.CostData, error) {
+	cost, _, err := registry.FetchCostData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cost data: %w", err)
+	}
+
+	converter, err := newCurrencyConverter(configManager.GetConfig().Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up currency conversion: %w", err)
+	}
+	if converter != nil {
+		return converter.Convert(ctx, cost)
+	}
+
+	return cost, nil
+}
+
+// renderArtFrames renders the rainbow art for consecutive animation frames
+func renderArtFrames(ctx context.Context, registry *core.PluginRegistry, configManager *core.ConfigManager, cost *domain.CostData, displayConfig *domain.DisplayConfig, frameCount int) ([]*domain.ArtGrid, error) {
+	rainbowPlugin := display.NewRainbowTUIPlugin()
+	if err := rainbowPlugin.Initialize(map[string]interface{}{}); err != nil {
+		return nil, fmt.Errorf("failed to initialize display plugin: %w", err)
+	}
+
+	animator := core.NewAnimationController(registry, configManager)
+	costText := domain.FormatCost(cost.TotalCost, cost.Currency, displayConfig.Format)
+
+	grids := make([]*domain.ArtGrid, 0, frameCount)
+	for frameNumber := 0; frameNumber < frameCount; frameNumber++ {
+		frame, err := animator.GenerateAnimationFrame(ctx, costText, frameNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate animation frame: %w", err)
+		}
+
+		grid, err := rainbowPlugin.RenderGrid(ctx, &domain.DisplayData{
+			Cost:      cost,
+			Animation: frame,
+			Config:    displayConfig,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if exportSize == "" {
+			grid = grid.Trim(exportMarginColumns, exportMarginRows)
+		}
+		grids = append(grids, grid)
+	}
+
+	return grids, nil
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// pluginsCmd groups the plugin management commands
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List and manage plugins",
+	Long: `List the built-in and external plugins, show their details and
+enable or disable them. Disabled plugins are recorded in the config file
+under plugins.disabled and are not loaded.`,
+	Args: cobra.NoArgs,
+}
+
+var pluginsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available plugins",
+	Args:  cobra.NoArgs,
+	RunE:  runPluginsList,
+}
+
+var pluginsInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show the details and config schema of a plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPluginsInfo,
+}
+
+var pluginsEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Load a disabled plugin again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPluginEnabled(cmd, args[0], true)
+	},
+}
+
+var pluginsDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Stop loading a plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPluginEnabled(cmd, args[0], false)
+	},
+}
+
+func init() {
+	pluginsCmd.AddCommand(pluginsListCmd, pluginsInfoCmd, pluginsEnableCmd, pluginsDisableCmd)
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+// runPluginsList prints a table of all plugins
+func runPluginsList(cmd *cobra.Command, args []string) error {
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "NAME\tKIND\tVERSION\tSTATUS\tDESCRIPTION")
+	for _, info := range collectPlugins(configManager) {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", info.Name, info.Kind, info.Version, pluginStatus(info), info.Description)
+	}
+	return writer.Flush()
+}
+
+// runPluginsInfo prints the details of one plugin
+func runPluginsInfo(cmd *cobra.Command, args []string) error {
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	info, err := findPlugin(collectPlugins(configManager), args[0])
+	if err != nil {
+		return err
+	}
+
+	writePluginInfo(cmd.OutOrStdout(), info)
+	return nil
+}
+
+// setPluginEnabled records in the config file whether a plugin is loaded
+func setPluginEnabled(cmd *cobra.Command, name string, enabled bool) error {
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	// Disabled plugins are known from the config file, so only check others exist
+	if configManager.IsPluginEnabled(name) {
+		if _, err := findPlugin(collectPlugins(configManager), name); err != nil {
+			return err
+		}
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	if configManager.IsPluginEnabled(name) == enabled {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Plugin '%s' is already %s\n", name, state)
+		return nil
+	}
+
+	if err := configManager.SetPluginEnabled(name, enabled); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Plugin '%s' %s in %s\n", name, state, configManager.ConfigPath())
+	return nil
+}
+
+// collectPlugins describes the built-in and external plugins. External plugins
+// with a manifest are described without starting them; others are started for the handshake.
+func collectPlugins(configManager *core.ConfigManager) []domain.PluginInfo {
+	var plugins []domain.PluginInfo
+	for _, plugin := range append(builtinPlugins(false), optionalPlugins()...) {
+		plugins = append(plugins, configManager.DescribePlugin(plugin, "built-in"))
+	}
+
+	for _, spec := range externalPluginSpecs(configManager) {
+		if manifest := spec.Manifest; manifest != nil {
+			plugins = append(plugins, domain.PluginInfo{
+				Name:         manifest.Name,
+				Kind:         manifest.Kind,
+				Version:      manifest.Version,
+				Description:  manifest.Description,
+				Source:       spec.Command,
+				Enabled:      configManager.IsPluginEnabled(manifest.Name),
+				ConfigSchema: manifest.ConfigSchema,
+			})
+			continue
+		}
+
+		plugin, err := loadExternalPlugin(spec)
+		if err != nil {
+			log.Printf("Warning: Skipping external plugin: %v", err)
+			continue
+		}
+		plugins = append(plugins, configManager.DescribePlugin(plugin, spec.Command))
+		_ = plugin.Shutdown()
+	}
+
+	return plugins
+}
+
+// findPlugin returns the plugin with the given name
+func findPlugin(plugins []domain.PluginInfo, name string) (domain.PluginInfo, error) {
+	for _, info := range plugins {
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return domain.PluginInfo{}, fmt.Errorf("plugin '%s' not found, see 'ccugorg plugins list'", name)
+}
+
+// pluginStatus returns whether the plugin is loaded
+func pluginStatus(info domain.PluginInfo) string {
+	if info.Enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// writePluginInfo writes the details and config schema of a plugin
+func writePluginInfo(out io.Writer, info domain.PluginInfo) {
+	_, _ = fmt.Fprintf(out, "Name:        %s\n", info.Name)
+	_, _ = fmt.Fprintf(out, "Kind:        %s\n", info.Kind)
+	_, _ = fmt.Fprintf(out, "Version:     %s\n", info.Version)
+	_, _ = fmt.Fprintf(out, "Status:      %s\n", pluginStatus(info))
+	_, _ = fmt.Fprintf(out, "Source:      %s\n", info.Source)
+	_, _ = fmt.Fprintf(out, "Description: %s\n", info.Description)
+
+	if len(info.ConfigSchema) == 0 {
+		_, _ = fmt.Fprintln(out, "Config:      none")
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, "Config:")
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, option := range info.ConfigSchema {
+		var details []string
+		if option.Required {
+			details = append(details, "required")
+		}
+		if option.Default != nil {
+			details = append(details, fmt.Sprintf("default %v", option.Default))
+		}
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\n", option.Name, option.Type, strings.Join(details, ", "), option.Description)
+	}
+	_ = writer.Flush()
+}
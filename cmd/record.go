@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/asciicast"
+	"github.com/spf13/cobra"
+)
+
+// recordCmd records the display into an asciicast file
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record the display into an asciicast file",
+	Long: `Record the active display and animation into an asciicast v2 file
+that can be played with asciinema or embedded in web pages.
+No terminal is needed: frames are rendered at the configured animation
+speed on a fixed canvas (display.width x display.height unless --size is given).`,
+	Args: cobra.NoArgs,
+	RunE: runRecord,
+}
+
+// Record flag variables
+var (
+	recordDuration time.Duration
+	recordOut      string
+	recordSize     string
+)
+
+func init() {
+	recordCmd.Flags().DurationVar(&recordDuration, "duration", 10*time.Second, "Length of the recording")
+	recordCmd.Flags().StringVar(&recordOut, "out", "ccugorg.cast", "Output file, - for stdout")
+	recordCmd.Flags().StringVar(&recordSize, "size", "", "Canvas size as COLUMNSxROWS (e.g., 120x40)")
+
+	// Appearance flags shared with the root command
+	recordCmd.Flags().StringVar(&animationSpeed, "animation-speed", "", "Animation speed (e.g., 100ms)")
+	recordCmd.Flags().StringVar(&animationPattern, "animation-pattern", "", "Animation pattern (rainbow, gradient, pulse, wave)")
+	recordCmd.Flags().StringVar(&theme, "theme", "", "Color theme (see 'ccugorg themes')")
+	recordCmd.Flags().StringVar(&currency, "currency", "", "Display currency (e.g., USD, EUR, JPY, GBP)")
+	recordCmd.Flags().StringVar(&displayName, "display", "", "Display plugin (rainbow-display, dashboard, table)")
+
+	rootCmd.AddCommand(recordCmd)
+}
+
+// runRecord renders the display headlessly and writes the frames as asciicast events
+func runRecord(cmd *cobra.Command, args []string) error {
+	if recordDuration <= 0 {
+		return fmt.Errorf("recording duration must be positive")
+	}
+
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	width, height := configManager.GetConfig().Display.Width, configManager.GetConfig().Display.Height
+	if recordSize != "" {
+		if width, height, err = parseCanvasSize(recordSize); err != nil {
+			return err
+		}
+	}
+
+	// Recordings are played back elsewhere, so colors do not depend on this terminal
+	if configManager.GetConfig().Display.ColorMode == domain.ColorModeNever {
+		configManager.SetColorProfile(domain.ColorProfileNone)
+	} else {
+		configManager.SetColorProfile(domain.ColorProfileTrueColor)
+	}
+
+	registry, err := setupPlugins(configManager, false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := registry.ShutdownAll(); err != nil {
+			log.Printf("Warning: Error during plugin shutdown: %v", err)
+		}
+	}()
+
+	model, err := newModel(context.Background(), registry, configManager)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if recordOut != "-" {
+		file, err := os.Create(recordOut)
+		if err != nil {
+			return fmt.Errorf("failed to create recording: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	writer, err := asciicast.NewWriter(out, asciicast.Header{
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Title:     "ccugorg",
+		Env:       map[string]string{"TERM": "xterm-256color", "SHELL": "/bin/sh"},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = model.Record(recordDuration, width, height, func(offset time.Duration, view string) error {
+		frame := asciicast.Frame(view)
+		if offset == 0 {
+			frame = asciicast.ResetScreen + frame
+		}
+		return writer.WriteOutput(offset, frame)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record: %w", err)
+	}
+
+	if recordOut != "-" {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Recorded %s to %s\n", recordDuration, recordOut)
+	}
+	return nil
+}
+
+// parseCanvasSize parses a canvas size such as "120x40"
+func parseCanvasSize(value string) (int, int, error) {
+	columns, rows, found := strings.Cut(strings.ToLower(value), "x")
+	width, widthErr := strconv.Atoi(columns)
+	height, heightErr := strconv.Atoi(rows)
+	if !found || widthErr != nil || heightErr != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid canvas size '%s', expected COLUMNSxROWS", value)
+	}
+	return width, height, nil
+}
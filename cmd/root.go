@@ -4,15 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"maps"
+	"os"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/costcache"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/history"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/rates"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/snapshot"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/external"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 )
 
@@ -31,7 +43,14 @@ Claude API usage costs with beautiful rainbow animations and ASCII art.`,
 var (
 	animationSpeed   string
 	animationPattern string
+	theme            string
 	noAnimation      bool
+	colorMode        string
+	currency         string
+	displayName      string
+	saveSettings     bool
+	kiosk            bool
+	configPath       string
 	bankruptcy       bool
 )
 
@@ -40,6 +59,13 @@ func init() {
 	rootCmd.Flags().StringVar(&animationSpeed, "animation-speed", "", "Animation speed (e.g., 100ms)")
 	rootCmd.Flags().StringVar(&animationPattern, "animation-pattern", "", "Animation pattern (rainbow, gradient, pulse, wave)")
 	rootCmd.Flags().BoolVar(&noAnimation, "no-animation", false, "Disable animation")
+	rootCmd.Flags().StringVar(&theme, "theme", "", "Color theme (see 'ccugorg themes')")
+	rootCmd.Flags().StringVar(&currency, "currency", "", "Display currency (e.g., USD, EUR, JPY, GBP)")
+	rootCmd.Flags().StringVar(&displayName, "display", "", "Display plugin (rainbow-display, dashboard, table)")
+	rootCmd.Flags().BoolVar(&saveSettings, "save-settings", false, "Save animation settings changed in the TUI to the config file on exit")
+	rootCmd.Flags().BoolVar(&kiosk, "kiosk", false, "Run unattended: hide hints, drift the art and rotate views")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "", "Color output mode (auto, always, never)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to config file")
 
 	// Hidden bankruptcy flag
 	rootCmd.Flags().BoolVar(&bankruptcy, "bankruptcy", false, "")
@@ -51,34 +77,103 @@ func runApplication(cmd *cobra.Command, args []string) error {
 	// Create context
 	ctx := context.Background()
 
-	// Convert cobra flags to our flag config structure
-	flagConfig, err := convertCobraFlags()
+	// Load configuration from file and flags
+	configManager, err := loadConfiguration()
 	if err != nil {
-		return fmt.Errorf("failed to convert flags: %w", err)
+		return err
 	}
 
-	// Initialize configuration manager
-	configManager := core.NewConfigManager()
-	if err := configManager.LoadConfig(""); err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+	// Set up the plugins and the TUI model
+	registry, err := setupPlugins(configManager, bankruptcy)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		if err := registry.ShutdownAll(); err != nil {
+			log.Printf("Warning: Error during plugin shutdown: %v", err)
+		}
+	}()
 
-	// Apply command line flags to override configuration
-	if err := configManager.ApplyFlagsToConfig(flagConfig); err != nil {
-		return fmt.Errorf("failed to apply command line flags: %w", err)
+	model, err := newModel(ctx, registry, configManager)
+	if err != nil {
+		return err
 	}
 
-	// Validate configuration
-	if err := configManager.ValidateConfig(); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+	// Record milestones in the history file
+	if historyPath := configManager.HistoryPath(); historyPath != "" {
+		model.SetHistoryStore(history.NewFileStore(historyPath))
+	}
+
+	// Show the cost of the previous run until the first fetch completes
+	if cachePath := configManager.CachePath(); cachePath != "" {
+		model.SetCostCache(costcache.NewFileStore(cachePath))
+	}
+
+	// Create TUI program. All-motion mouse reporting is needed for hover tooltips,
+	// which an unattended kiosk has no use for.
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if !configManager.GetKioskConfig().Enabled {
+		options = append(options, tea.WithMouseAllMotion())
+	}
+	program := tea.NewProgram(model, options...)
+
+	// Probe plugin health and share the local cost with the team while the TUI runs.
+	// Both are stopped and waited for before the plugins are shut down.
+	publisher, err := newTeamPublisher(registry, configManager)
+	if err != nil {
+		return err
+	}
+	healthCtx, stopHealthChecks := context.WithCancel(ctx)
+	var background sync.WaitGroup
+	stopBackground := func() {
+		stopHealthChecks()
+		background.Wait()
+	}
+	defer stopBackground()
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		registry.RunHealthChecks(healthCtx, configManager.GetConfig().Plugins.HealthCheckInterval)
+	}()
+	if publisher != nil {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			publisher.Run(healthCtx, configManager.GetTeamConfig().Interval)
+		}()
+	}
+
+	// Run the program
+	finalModel, err := program.Run()
+	if err != nil {
+		return fmt.Errorf("error running TUI program: %w", err)
+	}
+
+	stopBackground()
+	if publisher != nil && publisher.Err() != nil {
+		log.Printf("Warning: Failed to share team snapshot: %v", publisher.Err())
 	}
 
+	// Persist settings changed with the keyboard when requested
+	if configManager.GetConfig().App.SaveSettingsOnExit {
+		if m, ok := finalModel.(*tui.Model); ok && m.SettingsChanged() {
+			if err := configManager.SaveAnimationSettings(); err != nil {
+				log.Printf("Warning: Failed to save settings: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setupPlugins registers, initializes and verifies the plugins for the configuration
+func setupPlugins(configManager *core.ConfigManager, bankruptcyMode bool) (*core.PluginRegistry, error) {
 	// Update configuration for bankruptcy mode
-	if bankruptcy {
+	if bankruptcyMode {
 		if err := configManager.UpdateConfig(map[string]interface{}{
 			"plugins.datasource": "bankruptcy-datasource",
 		}); err != nil {
-			return fmt.Errorf("failed to update config for bankruptcy mode: %w", err)
+			return nil, fmt.Errorf("failed to update config for bankruptcy mode: %w", err)
 		}
 	}
 
@@ -86,39 +181,104 @@ func runApplication(cmd *cobra.Command, args []string) error {
 	registry := core.NewPluginRegistry(configManager)
 
 	// Register built-in plugins
-	if err := registerPlugins(registry, bankruptcy); err != nil {
-		return fmt.Errorf("failed to register plugins: %w", err)
+	if err := registerPlugins(registry, configManager, bankruptcyMode); err != nil {
+		return nil, fmt.Errorf("failed to register plugins: %w", err)
 	}
 
-	// Initialize plugins
-	if err := initializePlugins(registry); err != nil {
-		return fmt.Errorf("failed to initialize plugins: %w", err)
+	// Register external plugins, stopping their processes if setup fails
+	registerExternalPlugins(registry, configManager)
+
+	// Register the data source reading the team snapshots
+	if team := configManager.GetTeamConfig(); team.Dir != "" && configManager.IsPluginEnabled("shared-dir") {
+		if err := registry.RegisterDataSource(datasource.NewSharedDirPlugin(snapshot.NewDir(team.Dir), team.MaxAge)); err != nil {
+			_ = registry.ShutdownAll()
+			return nil, fmt.Errorf("failed to register shared-dir data source: %w", err)
+		}
+	}
+
+	// Register the composite data source over the registered ones
+	if err := registerCompositeDataSource(registry, configManager); err != nil {
+		_ = registry.ShutdownAll()
+		return nil, fmt.Errorf("failed to register composite data source: %w", err)
 	}
 
+	// Initialize plugins
+	initializePlugins(registry)
+
 	// Verify required plugins are available
 	if err := verifyRequiredPlugins(registry); err != nil {
-		return fmt.Errorf("required plugins not available: %w", err)
+		_ = registry.ShutdownAll()
+		return nil, fmt.Errorf("required plugins not available: %w", err)
 	}
 
-	// Create TUI model
+	// Validate theme palettes with the active animation plugin
+	animationPlugin, err := registry.GetActiveAnimation()
+	if err != nil {
+		_ = registry.ShutdownAll()
+		return nil, fmt.Errorf("active animation plugin not available: %w", err)
+	}
+	if err := configManager.ValidateThemes(animationPlugin); err != nil {
+		_ = registry.ShutdownAll()
+		return nil, fmt.Errorf("theme validation failed: %w", err)
+	}
+
+	return registry, nil
+}
+
+// newModel creates the TUI model with the configured key bindings and currency
+func newModel(ctx context.Context, registry *core.PluginRegistry, configManager *core.ConfigManager) (*tui.Model, error) {
+	keys, err := tui.NewKeyMap(configManager.GetConfig().Keys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key bindings: %w", err)
+	}
 	model := tui.NewModel(ctx, registry, configManager)
+	model.SetKeyMap(keys)
 
-	// Create TUI program
-	program := tea.NewProgram(model, tea.WithAltScreen())
+	// Convert costs into the display currency when configured
+	converter, err := newCurrencyConverter(configManager.GetConfig().Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up currency conversion: %w", err)
+	}
+	if converter != nil {
+		model.SetCurrencyConverter(converter)
+	}
 
-	// Setup cleanup
-	defer func() {
-		if err := registry.ShutdownAll(); err != nil {
-			log.Printf("Warning: Error during plugin shutdown: %v", err)
-		}
-	}()
+	return model, nil
+}
 
-	// Run the program
-	if _, err := program.Run(); err != nil {
-		return fmt.Errorf("error running TUI program: %w", err)
+// loadConfiguration loads the config file, applies command line flags,
+// validates the result and detects the terminal color profile
+func loadConfiguration() (*core.ConfigManager, error) {
+	// Convert cobra flags to our flag config structure
+	flagConfig, err := convertCobraFlags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert flags: %w", err)
 	}
 
-	return nil
+	// Initialize configuration manager
+	configManager := core.NewConfigManager()
+	if err := configManager.LoadConfig(flagConfig.ConfigPath); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Apply command line flags to override configuration
+	if err := configManager.ApplyFlagsToConfig(flagConfig); err != nil {
+		return nil, fmt.Errorf("failed to apply command line flags: %w", err)
+	}
+
+	// Validate configuration
+	if err := configManager.ValidateConfig(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Detect the terminal color profile
+	configManager.SetColorProfile(terminal.DetectColorProfile(
+		configManager.GetConfig().Display.ColorMode,
+		os.Getenv,
+		term.IsTerminal(os.Stdout.Fd()),
+	))
+
+	return configManager, nil
 }
 
 // convertCobraFlags converts cobra flag variables to FlagConfig structure
@@ -155,81 +315,293 @@ func convertCobraFlags() (*core.FlagConfig, error) {
 		flagConfig.Animation.Pattern = pattern
 	}
 
+	// Parse theme flag (validated against the available themes when applied)
+	flagConfig.Animation.Theme = theme
+
 	// Parse no-animation flag
 	if noAnimation {
 		enabled := false
 		flagConfig.Animation.Enabled = &enabled
 	}
 
+	// Parse color flag
+	if colorMode != "" {
+		mode, err := core.ParseColorMode(colorMode)
+		if err != nil {
+			return nil, err
+		}
+		flagConfig.ColorMode = mode
+	}
+
+	// Parse currency flag
+	if currency != "" {
+		if len(currency) != 3 {
+			return nil, fmt.Errorf("invalid currency code '%s'", currency)
+		}
+		flagConfig.Currency = currency
+	}
+
+	// Parse display flag (validated against the registered plugins at startup)
+	flagConfig.Display = displayName
+
+	// Parse config path flag
+	flagConfig.ConfigPath = configPath
+
+	// Parse save-settings flag
+	flagConfig.SaveSettings = saveSettings
+
+	// Parse kiosk flag
+	flagConfig.Kiosk = kiosk
+
 	// Parse bankruptcy flag
 	flagConfig.Bankruptcy = bankruptcy
 
 	return flagConfig, nil
 }
 
-// registerPlugins registers all built-in plugins
-func registerPlugins(registry *core.PluginRegistry, bankruptcyMode bool) error {
-	// Register appropriate data source plugin based on bankruptcy mode
+// builtinPlugins returns the plugins compiled into ccugorg. Bankruptcy mode
+// replaces the ccusage data source with the bankruptcy one.
+func builtinPlugins(bankruptcyMode bool) []interfaces.Plugin {
+	var dataSource interfaces.Plugin = datasource.NewCcusageCliPlugin()
 	if bankruptcyMode {
-		bankruptcyPlugin := datasource.NewBankruptcyDataSourcePlugin()
-		if err := registry.RegisterDataSource(bankruptcyPlugin); err != nil {
-			return fmt.Errorf("failed to register bankruptcy data source plugin: %w", err)
+		dataSource = datasource.NewBankruptcyDataSourcePlugin()
+	}
+
+	return []interfaces.Plugin{
+		dataSource,
+		animation.NewRainbowAnimationPlugin(),
+		display.NewRainbowTUIPlugin(),
+		display.NewDashboardPlugin(),
+		display.NewTablePlugin(),
+	}
+}
+
+// optionalPlugins returns the built-in plugins that are only registered in bankruptcy
+// mode or when configured, so that they can be listed and disabled like the others
+func optionalPlugins() []interfaces.Plugin {
+	return []interfaces.Plugin{
+		datasource.NewBankruptcyDataSourcePlugin(),
+		datasource.NewSharedDirPlugin(nil, 0),
+		datasource.NewCompositePlugin(nil, 0),
+	}
+}
+
+// registerPlugins registers the built-in plugins that are not disabled
+func registerPlugins(registry *core.PluginRegistry, configManager *core.ConfigManager, bankruptcyMode bool) error {
+	for _, plugin := range builtinPlugins(bankruptcyMode) {
+		if !configManager.IsPluginEnabled(plugin.Name()) {
+			continue
 		}
-	} else {
-		ccusagePlugin := datasource.NewCcusageCliPlugin()
-		if err := registry.RegisterDataSource(ccusagePlugin); err != nil {
-			return fmt.Errorf("failed to register ccusage CLI plugin: %w", err)
+		if err := registry.Register(plugin); err != nil {
+			return fmt.Errorf("failed to register plugin '%s': %w", plugin.Name(), err)
 		}
 	}
 
-	// Register animation plugins
-	rainbowAnimationPlugin := animation.NewRainbowAnimationPlugin()
-	if err := registry.RegisterAnimation(rainbowAnimationPlugin); err != nil {
-		return fmt.Errorf("failed to register rainbow animation plugin: %w", err)
+	return nil
+}
+
+// externalPluginSpecs returns the plugins declared in the configuration followed
+// by those found in the plugin directory
+func externalPluginSpecs(configManager *core.ConfigManager) []domain.ExternalPluginConfig {
+	discovered, err := external.Discover(configManager.PluginDirectory())
+	if err != nil {
+		log.Printf("Warning: %v", err)
 	}
+	return append(slices.Clone(configManager.GetConfig().Plugins.External), discovered...)
+}
 
-	// Register display plugins
-	rainbowDisplayPlugin := display.NewRainbowTUIPlugin()
-	if err := registry.RegisterDisplay(rainbowDisplayPlugin); err != nil {
-		return fmt.Errorf("failed to register rainbow display plugin: %w", err)
+// loadExternalPlugin starts an external plugin, giving up after the default timeout
+func loadExternalPlugin(spec domain.ExternalPluginConfig) (interfaces.Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), domain.DefaultExternalPluginTimeout)
+	defer cancel()
+	return external.Load(ctx, spec)
+}
+
+// registerExternalPlugins loads the external plugins that are not disabled.
+// Plugins that fail to load are skipped with a warning.
+func registerExternalPlugins(registry *core.PluginRegistry, configManager *core.ConfigManager) {
+	for _, spec := range externalPluginSpecs(configManager) {
+		// Plugins named in their declaration are skipped without starting them
+		if spec.Name != "" && !configManager.IsPluginEnabled(spec.Name) {
+			continue
+		}
+
+		plugin, err := loadExternalPlugin(spec)
+		if err != nil {
+			log.Printf("Warning: Skipping external plugin: %v", err)
+			continue
+		}
+
+		if !configManager.IsPluginEnabled(plugin.Name()) {
+			_ = plugin.Shutdown()
+			continue
+		}
+		if err := registry.Register(plugin); err != nil {
+			_ = plugin.Shutdown()
+			log.Printf("Warning: Skipping external plugin '%s': %v", plugin.Name(), err)
+		}
 	}
+}
 
-	return nil
+// newTeamPublisher creates the publisher sharing the local cost in the team directory,
+// or nil when sharing is off. The member defaults to the hostname.
+func newTeamPublisher(registry *core.PluginRegistry, configManager *core.ConfigManager) (*snapshot.Publisher, error) {
+	team := configManager.GetTeamConfig()
+	if !team.Share {
+		return nil, nil
+	}
+
+	source, err := registry.GetDataSource(team.DataSource)
+	if err != nil {
+		return nil, fmt.Errorf("team snapshot data source not available: %w", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname for team snapshots: %w", err)
+	}
+	member := team.Member
+	if member == "" {
+		member = host
+	}
+
+	return snapshot.NewPublisher(snapshot.NewDir(team.Dir), source, member, host), nil
 }
 
-// initializePlugins initializes all registered plugins
-func initializePlugins(registry *core.PluginRegistry) error {
-	plugins := registry.ListPlugins()
+// registerCompositeDataSource registers the data source adding up the configured sources.
+// Sources with their own settings run as a separate instance of a built-in data source.
+func registerCompositeDataSource(registry *core.PluginRegistry, configManager *core.ConfigManager) error {
+	composite := configManager.GetConfig().Plugins.Composite
+	if len(composite.Sources) == 0 || !configManager.IsPluginEnabled("composite") {
+		return nil
+	}
 
-	for _, plugin := range plugins {
-		if err := registry.InitializePlugin(plugin); err != nil {
-			return fmt.Errorf("failed to initialize plugin '%s': %w", plugin.Name(), err)
+	members := make([]datasource.CompositeMember, 0, len(composite.Sources))
+	for _, source := range composite.Sources {
+		if source.Config == nil {
+			dataSource, err := registry.GetDataSource(source.DataSource)
+			if err != nil {
+				shutdownOwned(members)
+				return fmt.Errorf("source '%s': %w", source.Name(), err)
+			}
+			members = append(members, datasource.CompositeMember{Label: source.Name(), Source: dataSource})
+			continue
 		}
+
+		dataSource := newBuiltinDataSource(source.DataSource)
+		if dataSource == nil {
+			shutdownOwned(members)
+			return fmt.Errorf("source '%s': only built-in data sources accept settings, '%s' is not one", source.Name(), source.DataSource)
+		}
+		// The source settings override the global ones
+		config := configManager.PluginConfig(source.DataSource)
+		maps.Copy(config, source.Config)
+		if err := dataSource.Initialize(config); err != nil {
+			shutdownOwned(members)
+			return fmt.Errorf("source '%s': %w", source.Name(), err)
+		}
+		members = append(members, datasource.CompositeMember{Label: source.Name(), Source: dataSource, Owned: true})
 	}
 
+	if err := registry.RegisterDataSource(datasource.NewCompositePlugin(members, composite.Timeout)); err != nil {
+		shutdownOwned(members)
+		return err
+	}
 	return nil
 }
 
+// shutdownOwned shuts down the composite members created for it, when it is not registered
+func shutdownOwned(members []datasource.CompositeMember) {
+	for _, member := range members {
+		if member.Owned {
+			_ = member.Source.Shutdown()
+		}
+	}
+}
+
+// newBuiltinDataSource creates a built-in data source by name, or nil for unknown names
+func newBuiltinDataSource(name string) interfaces.DataSourcePlugin {
+	switch name {
+	case "ccusage-cli":
+		return datasource.NewCcusageCliPlugin()
+	case "bankruptcy-datasource":
+		return datasource.NewBankruptcyDataSourcePlugin()
+	default:
+		return nil
+	}
+}
+
+// initializePlugins initializes all registered plugins. Plugins that fail are
+// reported and replaced by another plugin of the same kind where possible.
+func initializePlugins(registry *core.PluginRegistry) {
+	if err := registry.InitializeAll(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
 // verifyRequiredPlugins verifies that all required plugins are available
 func verifyRequiredPlugins(registry *core.PluginRegistry) error {
 	// Check data source plugin
-	if _, err := registry.GetActiveDataSource(); err != nil {
+	dataSourcePlugin, err := registry.GetActiveDataSource()
+	if err == nil {
+		err = requireUsable(registry, dataSourcePlugin)
+	}
+	if err != nil {
 		return fmt.Errorf("active data source plugin not available: %w", err)
 	}
 
-	// Check display plugin
-	if _, err := registry.GetActiveDisplay(); err != nil {
+	// Check display plugins
+	if _, err := registry.GetActiveDisplays(); err != nil {
+		return fmt.Errorf("active display plugin not available: %w", err)
+	}
+	displayPlugin, err := registry.GetActiveDisplay()
+	if err == nil {
+		err = requireUsable(registry, displayPlugin)
+	}
+	if err != nil {
 		return fmt.Errorf("active display plugin not available: %w", err)
 	}
 
 	// Check animation plugin
-	if _, err := registry.GetActiveAnimation(); err != nil {
+	animationPlugin, err := registry.GetActiveAnimation()
+	if err == nil {
+		err = requireUsable(registry, animationPlugin)
+	}
+	if err != nil {
 		return fmt.Errorf("active animation plugin not available: %w", err)
 	}
 
 	return nil
 }
 
+// requireUsable returns an error unless the plugin initialized successfully
+func requireUsable(registry *core.PluginRegistry, plugin interfaces.Plugin) error {
+	if state := registry.PluginState(plugin); !state.IsUsable() {
+		return fmt.Errorf("plugin '%s' is %s", plugin.Name(), state)
+	}
+	return nil
+}
+
+// newCurrencyConverter creates the currency converter for the configured display currency
+func newCurrencyConverter(config core.CurrencyConfig) (*core.CurrencyConverter, error) {
+	if config.Display == "" {
+		return nil, nil
+	}
+
+	var provider interfaces.RateProvider
+	if config.RatesFile != "" {
+		provider = rates.NewFileProvider(config.RatesFile)
+	} else {
+		staticProvider, err := rates.NewStaticProvider(config.RatesBase, config.RatesDate, config.Rates)
+		if err != nil {
+			return nil, err
+		}
+		provider = staticProvider
+	}
+
+	return core.NewCurrencyConverter(provider, config.Display), nil
+}
+
 // Execute executes the root command
 func Execute() error {
 	return rootCmd.Execute()
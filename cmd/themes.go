@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/spf13/cobra"
+)
+
+// themesCmd previews the available color themes
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Preview the available color themes",
+	Long: `Preview every built-in and user-defined color theme.
+Select a theme with --theme or the animation.theme config key.`,
+	Args: cobra.NoArgs,
+	RunE: runThemes,
+}
+
+// themePreviewText is the sample text rendered with each theme
+const themePreviewText = "$1,234.56"
+
+func init() {
+	rootCmd.AddCommand(themesCmd)
+}
+
+// runThemes prints a preview line for each theme
+func runThemes(cmd *cobra.Command, args []string) error {
+	configManager, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	if err := animationPlugin.Initialize(map[string]interface{}{}); err != nil {
+		return fmt.Errorf("failed to initialize animation plugin: %w", err)
+	}
+
+	config := configManager.GetConfig()
+	themes := configManager.ListThemes()
+
+	nameWidth := 0
+	for _, theme := range themes {
+		nameWidth = max(nameWidth, len(theme.Name))
+	}
+
+	out := cmd.OutOrStdout()
+	for _, theme := range themes {
+		validationErr := core.ValidateTheme(theme, configManager.GetAnimationConfig(), animationPlugin)
+		isCurrent := strings.EqualFold(theme.Name, config.Animation.Theme) ||
+			(config.Animation.Theme == "" && theme.Name == core.DefaultThemeName)
+		writeThemePreview(out, theme, nameWidth, isCurrent, validationErr, animationPlugin, config.Display.ColorProfile)
+	}
+
+	return nil
+}
+
+// writeThemePreview writes the name, swatches and a sample of a theme
+func writeThemePreview(out io.Writer, theme domain.Theme, nameWidth int, isCurrent bool, validationErr error, animationPlugin *animation.RainbowAnimationPlugin, profile domain.ColorProfile) {
+	marker := " "
+	if isCurrent {
+		marker = "*"
+	}
+
+	_, _ = fmt.Fprintf(out, "%s %-*s  %s\n", marker, nameWidth, theme.Name, theme.Description)
+
+	indent := strings.Repeat(" ", nameWidth+4)
+	if validationErr != nil {
+		_, _ = fmt.Fprintf(out, "%s(invalid: %v)\n\n", indent, validationErr)
+		return
+	}
+
+	// One swatch per palette color
+	var swatches strings.Builder
+	for _, color := range theme.Colors {
+		swatches.WriteString(colorize("██", color, profile))
+	}
+
+	// The sample text animated with the theme's palette
+	var sample strings.Builder
+	frame, err := animationPlugin.GenerateFrame(context.Background(), themePreviewText, 0, &domain.AnimationConfig{
+		Speed:   1,
+		Colors:  theme.Colors,
+		Enabled: true,
+		Pattern: domain.PatternRainbow,
+	})
+	if err == nil {
+		for i, char := range []rune(themePreviewText) {
+			sample.WriteString(colorize(string(char), frame.Colors[i%len(frame.Colors)], profile))
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "%s%s  %s\n\n", indent, swatches.String(), sample.String())
+}
+
+// colorize wraps text in the foreground color closest to the hex color in the profile
+func colorize(text, color string, profile domain.ColorProfile) string {
+	sequence := terminal.ForegroundSequence(color, profile)
+	if sequence == "" {
+		return text
+	}
+	return sequence + text + terminal.ResetSequence
+}
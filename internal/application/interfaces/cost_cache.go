@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// CostCacheStore defines the interface for keeping cost data between runs
+type CostCacheStore interface {
+	Save(ctx context.Context, cached domain.CachedCost) error
+	Load(ctx context.Context) (*domain.CachedCost, error) // Nil when nothing is cached
+}
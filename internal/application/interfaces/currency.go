@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// RateProvider defines the interface for exchange rate sources
+type RateProvider interface {
+	Name() string
+	GetRates(ctx context.Context) (*domain.ExchangeRates, error)
+}
+
+// CurrencyConverter defines the use case for converting cost data for display
+type CurrencyConverter interface {
+	Convert(ctx context.Context, data *domain.CostData) (*domain.CostData, error)
+	TargetCurrency() string
+}
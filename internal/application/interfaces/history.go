@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// HistoryStore defines the interface for persisting notable events
+type HistoryStore interface {
+	Record(ctx context.Context, entry domain.HistoryEntry) error
+	List(ctx context.Context) ([]domain.HistoryEntry, error)
+}
@@ -41,12 +41,24 @@ type AnimationPlugin interface {
 	ValidateAnimationConfig(config *domain.AnimationConfig) error
 }
 
+// ConfigurablePlugin is implemented by plugins that describe the settings they accept
+type ConfigurablePlugin interface {
+	Plugin
+	ConfigSchema() []domain.ConfigOption
+}
+
+// HealthChecker is implemented by plugins that can report whether they are able to serve requests
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
 // DisplayCapabilities represents the capabilities of a display plugin
 type DisplayCapabilities struct {
 	MaxWidth        int  `json:"max_width"`
 	MaxHeight       int  `json:"max_height"`
 	SupportsColor   bool `json:"supports_color"`
 	SupportsUnicode bool `json:"supports_unicode"`
+	ShowsBreakdown  bool `json:"shows_breakdown"` // Renders its own model and daily breakdown
 }
 
 // PluginRegistry defines the interface for plugin management
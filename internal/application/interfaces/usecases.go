@@ -47,4 +47,7 @@ type AppStatus struct {
 	ActivePlugins []string         `json:"active_plugins"`
 	ErrorCount    int              `json:"error_count"`
 	LastError     string           `json:"last_error,omitempty"`
+
+	DataSource     string `json:"data_source,omitempty"`     // Data source that served the current cost
+	FallbackReason string `json:"fallback_reason,omitempty"` // Why earlier data sources were passed over
 }
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// AnimationController implements the Animator use case on top of the active animation plugin
+type AnimationController struct {
+	mu            sync.RWMutex
+	registry      *PluginRegistry
+	configManager *ConfigManager
+	paused        bool
+}
+
+// NewAnimationController creates a new animation controller
+func NewAnimationController(registry *PluginRegistry, configManager *ConfigManager) *AnimationController {
+	return &AnimationController{
+		registry:      registry,
+		configManager: configManager,
+	}
+}
+
+// GenerateAnimationFrame generates a frame with the active animation plugin and current configuration
+func (ac *AnimationController) GenerateAnimationFrame(ctx context.Context, text string, frameNumber int) (*domain.AnimationFrame, error) {
+	plugin, err := ac.registry.GetActiveAnimation()
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin.GenerateFrame(ctx, text, frameNumber, ac.configManager.GetAnimationConfig())
+}
+
+// GetAnimationConfig returns the current animation configuration
+func (ac *AnimationController) GetAnimationConfig(ctx context.Context) (*domain.AnimationConfig, error) {
+	config := ac.configManager.GetAnimationConfig()
+	if config == nil {
+		return nil, fmt.Errorf("no animation configuration available")
+	}
+	return config, nil
+}
+
+// UpdateAnimationConfig validates the configuration with the active animation plugin and applies it
+func (ac *AnimationController) UpdateAnimationConfig(ctx context.Context, config *domain.AnimationConfig) error {
+	plugin, err := ac.registry.GetActiveAnimation()
+	if err != nil {
+		return err
+	}
+
+	if err := plugin.ValidateAnimationConfig(config); err != nil {
+		return fmt.Errorf("invalid animation config: %w", err)
+	}
+
+	ac.configManager.SetAnimationConfig(config)
+	return nil
+}
+
+// StartAnimation resumes a paused animation
+func (ac *AnimationController) StartAnimation(ctx context.Context) error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.paused = false
+	return nil
+}
+
+// StopAnimation pauses the animation on its current frame
+func (ac *AnimationController) StopAnimation(ctx context.Context) error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.paused = true
+	return nil
+}
+
+// IsPaused returns whether the animation is paused
+func (ac *AnimationController) IsPaused() bool {
+	ac.mu.RLock()
+	defer ac.mu.RUnlock()
+
+	return ac.paused
+}
+
+// SupportedPatterns returns the patterns supported by the active animation plugin
+func (ac *AnimationController) SupportedPatterns() []domain.AnimationPattern {
+	plugin, err := ac.registry.GetActiveAnimation()
+	if err != nil {
+		return nil
+	}
+	return plugin.GetSupportedPatterns()
+}
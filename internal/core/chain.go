@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// passedSource is a data source that did not serve the data and why
+type passedSource struct {
+	name   string
+	reason string
+}
+
+// FetchCostData fetches cost data from the configured data sources in turn until one
+// succeeds. Failed data sources are tried last.
+// The report tells which data source served the data and why the others were passed over.
+func (pr *PluginRegistry) FetchCostData(ctx context.Context) (*domain.CostData, domain.FetchReport, error) {
+	var passed []passedSource
+	var deferred []interfaces.DataSourcePlugin
+	var errs []error
+
+	fetch := func(plugin interfaces.DataSourcePlugin) (*domain.CostData, bool) {
+		data, err := plugin.FetchCostData(ctx)
+		pr.RecordResult(plugin, err)
+		if err != nil {
+			passed = append(passed, passedSource{plugin.Name(), err.Error()})
+			errs = append(errs, err)
+			return nil, false
+		}
+		return data, true
+	}
+
+	for _, name := range pr.configManager.DataSourceNames() {
+		plugin, err := pr.GetDataSource(name)
+		if err != nil {
+			passed = append(passed, passedSource{name, "not available"})
+			continue
+		}
+
+		if state := pr.PluginState(plugin); state == domain.PluginStateFailed || state == domain.PluginStateStopped {
+			passed = append(passed, passedSource{name, string(state)})
+			deferred = append(deferred, plugin)
+			continue
+		}
+
+		if data, ok := fetch(plugin); ok {
+			return data, pr.recordFetch(plugin.Name(), passed), nil
+		}
+	}
+
+	for _, plugin := range deferred {
+		if data, ok := fetch(plugin); ok {
+			passed = slices.DeleteFunc(passed, func(p passedSource) bool { return p.name == plugin.Name() })
+			return data, pr.recordFetch(plugin.Name(), passed), nil
+		}
+	}
+
+	report := pr.recordFetch("", passed)
+	switch len(errs) {
+	case 0:
+		return nil, report, fmt.Errorf("no data source available: %s", report.Reason)
+	case 1:
+		return nil, report, errs[0]
+	default:
+		return nil, report, fmt.Errorf("all data sources failed: %w", errors.Join(errs...))
+	}
+}
+
+// LastFetch returns the report of the most recent fetch
+func (pr *PluginRegistry) LastFetch() domain.FetchReport {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	return pr.lastFetch
+}
+
+// recordFetch remembers which data source served the data and why the others were passed over
+func (pr *PluginRegistry) recordFetch(source string, passed []passedSource) domain.FetchReport {
+	reasons := make([]string, len(passed))
+	for i, p := range passed {
+		reasons[i] = p.name + ": " + p.reason
+	}
+	report := domain.FetchReport{Source: source, Reason: strings.Join(reasons, "; ")}
+
+	pr.mu.Lock()
+	pr.lastFetch = report
+	pr.mu.Unlock()
+	return report
+}
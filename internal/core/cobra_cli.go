@@ -13,9 +13,16 @@ type FlagConfig struct {
 	Animation struct {
 		Speed   time.Duration
 		Pattern domain.AnimationPattern
+		Theme   string
 		Enabled *bool
 	}
-	Bankruptcy bool
+	ColorMode    domain.ColorMode
+	Currency     string
+	Display      string
+	ConfigPath   string
+	SaveSettings bool
+	Kiosk        bool
+	Bankruptcy   bool
 }
 
 // NewRootCommand creates the root cobra command
@@ -33,6 +40,13 @@ Claude API usage costs with beautiful rainbow animations and ASCII art.`,
 	cmd.Flags().String("animation-speed", "", "Animation speed (e.g., 100ms)")
 	cmd.Flags().String("animation-pattern", "", "Animation pattern (rainbow, gradient, pulse, wave)")
 	cmd.Flags().Bool("no-animation", false, "Disable animation")
+	cmd.Flags().String("theme", "", "Color theme (see 'ccugorg themes')")
+	cmd.Flags().String("currency", "", "Display currency (e.g., USD, EUR, JPY, GBP)")
+	cmd.Flags().String("display", "", "Display plugin (rainbow-display, dashboard, table)")
+	cmd.Flags().Bool("save-settings", false, "Save animation settings changed in the TUI to the config file on exit")
+	cmd.Flags().Bool("kiosk", false, "Run unattended: hide hints, drift the art and rotate views")
+	cmd.PersistentFlags().String("color", "", "Color output mode (auto, always, never)")
+	cmd.PersistentFlags().String("config", "", "Path to config file")
 
 	// Hidden bankruptcy flag
 	cmd.Flags().Bool("bankruptcy", false, "")
@@ -85,6 +99,9 @@ func ParseCobraFlagsFromArgs(args []string) (*FlagConfig, error) {
 		flagConfig.Animation.Pattern = pattern
 	}
 
+	// Parse theme flag (validated against the available themes when applied)
+	flagConfig.Animation.Theme, _ = cmd.Flags().GetString("theme")
+
 	// Parse no-animation flag
 	noAnimation, _ := cmd.Flags().GetBool("no-animation")
 	if noAnimation {
@@ -92,6 +109,37 @@ func ParseCobraFlagsFromArgs(args []string) (*FlagConfig, error) {
 		flagConfig.Animation.Enabled = &enabled
 	}
 
+	// Parse color flag
+	colorStr, _ := cmd.Flags().GetString("color")
+	if colorStr != "" {
+		colorMode, err := ParseColorMode(colorStr)
+		if err != nil {
+			return nil, err
+		}
+		flagConfig.ColorMode = colorMode
+	}
+
+	// Parse currency flag
+	currency, _ := cmd.Flags().GetString("currency")
+	if currency != "" {
+		if len(currency) != 3 {
+			return nil, fmt.Errorf("invalid currency code '%s'", currency)
+		}
+		flagConfig.Currency = currency
+	}
+
+	// Parse display flag (validated against the registered plugins at startup)
+	flagConfig.Display, _ = cmd.Flags().GetString("display")
+
+	// Parse config path flag
+	flagConfig.ConfigPath, _ = cmd.Flags().GetString("config")
+
+	// Parse save-settings flag
+	flagConfig.SaveSettings, _ = cmd.Flags().GetBool("save-settings")
+
+	// Parse kiosk flag
+	flagConfig.Kiosk, _ = cmd.Flags().GetBool("kiosk")
+
 	// Parse bankruptcy flag
 	bankruptcy, _ := cmd.Flags().GetBool("bankruptcy")
 	flagConfig.Bankruptcy = bankruptcy
@@ -99,6 +147,16 @@ func ParseCobraFlagsFromArgs(args []string) (*FlagConfig, error) {
 	return flagConfig, nil
 }
 
+// ParseColorMode validates a --color flag value
+func ParseColorMode(value string) (domain.ColorMode, error) {
+	mode := domain.ColorMode(value)
+	switch mode {
+	case domain.ColorModeAuto, domain.ColorModeAlways, domain.ColorModeNever:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid color mode '%s'. Valid modes: auto, always, never", value)
+}
+
 // ParseCobraFlags parses cobra command flags and returns flag configuration (for backwards compatibility)
 func ParseCobraFlags(cmd *cobra.Command) (*FlagConfig, error) {
 	return ParseCobraFlagsFromArgs(cmd.Flags().Args())
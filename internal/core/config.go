@@ -1,58 +1,122 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	App        AppConfig
-	Display    DisplayConfig
-	Animation  AnimationConfig
-	DataSource DataSourceConfig
-	Plugins    PluginsConfig
+	App        AppConfig              `yaml:"app"`
+	Display    DisplayConfig          `yaml:"display"`
+	Animation  AnimationConfig        `yaml:"animation"`
+	DataSource DataSourceConfig       `yaml:"datasource"`
+	Currency   CurrencyConfig         `yaml:"currency"`
+	Milestones domain.MilestoneConfig `yaml:"milestones"`
+	Kiosk      domain.KioskConfig     `yaml:"kiosk"`
+	Team       domain.TeamConfig      `yaml:"team"`
+	Plugins    PluginsConfig          `yaml:"plugins"`
+	Themes     []domain.Theme         `yaml:"themes"` // User-defined themes
+	Keys       map[string][]string    `yaml:"keys"`   // Key binding overrides by action name
 }
 
 // AppConfig represents general application settings
 type AppConfig struct {
-	LogLevel    string
-	RefreshRate time.Duration
+	LogLevel           string        `yaml:"log_level"`
+	RefreshRate        time.Duration `yaml:"refresh_rate"`
+	SaveSettingsOnExit bool          `yaml:"save_settings_on_exit"` // Persist settings changed in the TUI
+	HistoryFile        string        `yaml:"history_file"`          // JSON Lines file for milestones and other events
+	CacheFile          string        `yaml:"cache_file"`            // Latest cost data, shown at the next startup
 }
 
 // DisplayConfig represents display-specific settings
 type DisplayConfig struct {
-	Width  int
-	Height int
+	Width        int                     `yaml:"width"`
+	Height       int                     `yaml:"height"`
+	NumberFormat domain.NumberFormat     `yaml:"number_format"`
+	ColorMode    domain.ColorMode        `yaml:"color"`
+	Transition   domain.TransitionConfig `yaml:"transition"` // Count-up transition when the cost changes
+	Dashboard    domain.DashboardConfig  `yaml:"dashboard"`  // Layout of the dashboard display
+	ColorProfile domain.ColorProfile     `yaml:"-"`          // Detected at startup from the terminal and ColorMode
 }
 
 // AnimationConfig represents animation-specific settings
 type AnimationConfig struct {
-	Enabled bool
-	Speed   time.Duration
-	Pattern domain.AnimationPattern
-	Colors  []string
+	Enabled bool                    `yaml:"enabled"`
+	Speed   time.Duration           `yaml:"speed"`
+	Pattern domain.AnimationPattern `yaml:"pattern"`
+	Theme   string                  `yaml:"theme"` // Named theme, overrides Colors when set
+	Colors  []string                `yaml:"colors"`
 }
 
 // DataSourceConfig represents data source settings
 type DataSourceConfig struct {
-	CcusagePath string
-	Timeout     time.Duration
-	CacheTime   time.Duration
+	CcusagePath string        `yaml:"ccusage_path"`
+	Timeout     time.Duration `yaml:"timeout"`
+	CacheTime   time.Duration `yaml:"cache_time"`
+}
+
+// CurrencyConfig represents currency conversion settings
+type CurrencyConfig struct {
+	Display   string             `yaml:"display"`    // Currency to display, empty keeps the source currency
+	RatesFile string             `yaml:"rates_file"` // Path to a JSON rate table
+	RatesBase string             `yaml:"rates_base"` // Base currency of the inline rates
+	RatesDate string             `yaml:"rates_date"` // Date of the inline rates (YYYY-MM-DD)
+	Rates     map[string]float64 `yaml:"rates"`      // Inline rates used when no rates file is set
 }
 
 // PluginsConfig represents plugin configuration
 type PluginsConfig struct {
-	DataSource string
-	Display    string
-	Animation  string
+	DataSource  string      `yaml:"-"`          // Data source tried first
+	DataSources PluginNames `yaml:"datasource"` // Data sources to try in order, a single name or a list
+	Display     string      `yaml:"display"`    // Display shown at startup
+	Displays    []string    `yaml:"displays"`   // Displays to switch between, in order
+	Animation   string      `yaml:"animation"`
+
+	Composite domain.CompositeConfig `yaml:"composite"` // Data sources added up by the composite data source
+
+	Directory string                        `yaml:"directory"` // Executables here are loaded as external plugins
+	External  []domain.ExternalPluginConfig `yaml:"external"`  // External plugins declared explicitly
+	Disabled  []string                      `yaml:"disabled"`  // Plugins that are not loaded
+
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"` // Time between plugin health probes, zero disables them
+}
+
+// PluginNames is a list of plugin names that may also be written as a single name
+type PluginNames []string
+
+// UnmarshalYAML accepts either a single name or a list of names
+func (p *PluginNames) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var name string
+		if err := node.Decode(&name); err != nil {
+			return err
+		}
+		*p = PluginNames{name}
+		return nil
+	}
+
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+	*p = names
+	return nil
 }
 
 // ConfigManager provides configuration management functionality
 type ConfigManager struct {
-	config *Config
+	config     *Config
+	configPath string
 }
 
 // NewConfigManager creates a new configuration manager
@@ -70,48 +134,198 @@ func getDefaultConfig() *Config {
 			RefreshRate: 1 * time.Second,
 		},
 		Display: DisplayConfig{
-			Width:  80,
-			Height: 24,
+			Width:        80,
+			Height:       24,
+			NumberFormat: domain.DefaultNumberFormat(),
+			ColorMode:    domain.ColorModeAuto,
+			Transition:   domain.DefaultTransitionConfig(),
+			Dashboard:    domain.DefaultDashboardConfig(),
+			ColorProfile: domain.ColorProfileTrueColor,
 		},
 		Animation: AnimationConfig{
 			Enabled: true,
 			Speed:   100 * time.Millisecond,
 			Pattern: domain.PatternRainbow,
-			Colors: []string{
-				"#FF0000", // Red
-				"#FF8000", // Orange
-				"#FFFF00", // Yellow
-				"#80FF00", // Light Green
-				"#00FF00", // Green
-				"#00FF80", // Cyan Green
-				"#00FFFF", // Cyan
-				"#0080FF", // Light Blue
-				"#0000FF", // Blue
-				"#8000FF", // Purple
-				"#FF00FF", // Magenta
-				"#FF0080", // Pink
-			},
+			Colors:  defaultThemeColors(),
 		},
 		DataSource: DataSourceConfig{
 			CcusagePath: "ccusage",
 			Timeout:     30 * time.Second,
 			CacheTime:   10 * time.Second,
 		},
+		Currency: CurrencyConfig{
+			RatesBase: domain.DefaultCurrency,
+		},
+		Milestones: domain.DefaultMilestoneConfig(),
+		Kiosk:      domain.DefaultKioskConfig(),
+		Team:       domain.DefaultTeamConfig(),
 		Plugins: PluginsConfig{
 			DataSource: "ccusage-cli",
 			Display:    "rainbow-display",
 			Animation:  "rainbow-animation",
+
+			HealthCheckInterval: 30 * time.Second,
 		},
 	}
 }
 
-// LoadConfig loads configuration with defaults only (no file loading)
+// DefaultConfigPath returns the default location of the configuration file
+func DefaultConfigPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "ccugorg", "config.yaml")
+}
+
+// DefaultHistoryPath returns the default location of the history file
+func DefaultHistoryPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "ccugorg", "history.jsonl")
+}
+
+// DefaultCachePath returns the default location of the cost cache file
+func DefaultCachePath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "ccugorg", "cost.json")
+}
+
+// DefaultPluginDirectory returns the default location of external plugin executables
+func DefaultPluginDirectory() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "ccugorg", "plugins")
+}
+
+// PluginDirectory returns the configured plugin directory, falling back to the default location
+func (cm *ConfigManager) PluginDirectory() string {
+	if cm.config.Plugins.Directory != "" {
+		return cm.config.Plugins.Directory
+	}
+	return DefaultPluginDirectory()
+}
+
+// IsPluginEnabled reports whether a plugin has not been disabled in the configuration
+func (cm *ConfigManager) IsPluginEnabled(name string) bool {
+	return !slices.Contains(cm.config.Plugins.Disabled, name)
+}
+
+// PluginConfig returns the settings a built-in plugin is initialized with.
+// The ccusage CLI data source takes the data source settings.
+func (cm *ConfigManager) PluginConfig(name string) map[string]interface{} {
+	config := make(map[string]interface{})
+	if name != "ccusage-cli" {
+		return config
+	}
+
+	dataSource := cm.config.DataSource
+	if dataSource.CcusagePath != "" {
+		config["ccusage_path"] = dataSource.CcusagePath
+	}
+	if dataSource.Timeout > 0 {
+		config["timeout"] = dataSource.Timeout.String()
+	}
+	config["cache_time"] = dataSource.CacheTime.String()
+	return config
+}
+
+// DescribePlugin returns the listing details of a plugin loaded from the given source
+func (cm *ConfigManager) DescribePlugin(plugin interfaces.Plugin, source string) domain.PluginInfo {
+	info := domain.PluginInfo{
+		Name:        plugin.Name(),
+		Kind:        PluginKindOf(plugin),
+		Version:     plugin.Version(),
+		Description: plugin.Description(),
+		Source:      source,
+		Enabled:     cm.IsPluginEnabled(plugin.Name()),
+	}
+	if configurable, ok := plugin.(interfaces.ConfigurablePlugin); ok {
+		info.ConfigSchema = configurable.ConfigSchema()
+	}
+	return info
+}
+
+// HistoryPath returns the configured history file, falling back to the default location
+func (cm *ConfigManager) HistoryPath() string {
+	if cm.config.App.HistoryFile != "" {
+		return cm.config.App.HistoryFile
+	}
+	return DefaultHistoryPath()
+}
+
+// CachePath returns the configured cost cache file, falling back to the default location
+func (cm *ConfigManager) CachePath() string {
+	if cm.config.App.CacheFile != "" {
+		return cm.config.App.CacheFile
+	}
+	return DefaultCachePath()
+}
+
+// LoadConfig loads configuration from a YAML file on top of the defaults.
+// An empty path loads the default config file if it exists.
 func (cm *ConfigManager) LoadConfig(configPath string) error {
-	// Configuration is already loaded with defaults in NewConfigManager
-	// This method is kept for compatibility but doesn't load from files
+	explicit := configPath != ""
+	if !explicit {
+		configPath = DefaultConfigPath()
+		if configPath == "" {
+			return nil
+		}
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			// Missing default config file simply keeps the defaults
+			return nil
+		}
+		return fmt.Errorf("failed to read config file '%s': %w", configPath, err)
+	}
+
+	// Without an explicit display, startup shows the first of the listed displays
+	defaultDisplay := cm.config.Plugins.Display
+	cm.config.Plugins.Display = ""
+
+	if err := yaml.Unmarshal(content, cm.config); err != nil {
+		cm.config.Plugins.Display = defaultDisplay
+		return fmt.Errorf("failed to parse config file '%s': %w", configPath, err)
+	}
+
+	// The first listed data source is tried first
+	if len(cm.config.Plugins.DataSources) > 0 {
+		cm.config.Plugins.DataSource = cm.config.Plugins.DataSources[0]
+	}
+
+	if cm.config.Plugins.Display == "" {
+		cm.config.Plugins.Display = defaultDisplay
+		if len(cm.config.Plugins.Displays) > 0 {
+			cm.config.Plugins.Display = cm.config.Plugins.Displays[0]
+		}
+	}
+
+	// A named theme replaces the configured colors
+	if cm.config.Animation.Theme != "" {
+		if err := cm.SetTheme(cm.config.Animation.Theme); err != nil {
+			return fmt.Errorf("invalid theme in config file '%s': %w", configPath, err)
+		}
+	}
+
+	cm.configPath = configPath
 	return nil
 }
 
+// ConfigPath returns the path of the loaded config file, or empty if none was loaded
+func (cm *ConfigManager) ConfigPath() string {
+	return cm.configPath
+}
+
 // GetConfig returns the current configuration
 func (cm *ConfigManager) GetConfig() *Config {
 	return cm.config
@@ -129,6 +343,9 @@ func (cm *ConfigManager) GetDisplayConfig() *domain.DisplayConfig {
 			Width:  cm.config.Display.Width,
 			Height: cm.config.Display.Height,
 		},
+		Format:       cm.config.Display.NumberFormat,
+		ColorProfile: cm.config.Display.ColorProfile,
+		Dashboard:    cm.config.Display.Dashboard,
 	}
 }
 
@@ -143,9 +360,40 @@ func (cm *ConfigManager) GetAnimationConfig() *domain.AnimationConfig {
 		Colors:  cm.config.Animation.Colors,
 		Enabled: cm.config.Animation.Enabled,
 		Pattern: cm.config.Animation.Pattern,
+		Theme:   cm.config.Animation.Theme,
 	}
 }
 
+// SetAnimationConfig applies a domain AnimationConfig to the core configuration
+func (cm *ConfigManager) SetAnimationConfig(config *domain.AnimationConfig) {
+	cm.config.Animation.Speed = config.Speed
+	cm.config.Animation.Colors = append([]string(nil), config.Colors...)
+	cm.config.Animation.Enabled = config.Enabled
+	cm.config.Animation.Pattern = config.Pattern
+	cm.config.Animation.Theme = config.Theme
+}
+
+// DisplayNames returns the displays to switch between, in order.
+// The startup display is listed first when it is not part of the configured list.
+func (cm *ConfigManager) DisplayNames() []string {
+	plugins := cm.config.Plugins
+	if slices.Contains(plugins.Displays, plugins.Display) {
+		return slices.Clone(plugins.Displays)
+	}
+	return append([]string{plugins.Display}, plugins.Displays...)
+}
+
+// DataSourceNames returns the data sources to try, in order.
+// The first data source is listed first when it is not part of the configured list.
+func (cm *ConfigManager) DataSourceNames() []string {
+	plugins := cm.config.Plugins
+	names := slices.Clone([]string(plugins.DataSources))
+	if slices.Contains(names, plugins.DataSource) {
+		return names
+	}
+	return append([]string{plugins.DataSource}, names...)
+}
+
 // UpdateConfig updates the configuration
 func (cm *ConfigManager) UpdateConfig(updates map[string]interface{}) error {
 	// Apply updates to specific fields
@@ -185,11 +433,68 @@ func (cm *ConfigManager) ValidateConfig() error {
 		return fmt.Errorf("invalid animation pattern: %s", cm.config.Animation.Pattern)
 	}
 
+	// Validate color mode
+	switch cm.config.Display.ColorMode {
+	case domain.ColorModeAuto, domain.ColorModeAlways, domain.ColorModeNever:
+	default:
+		return fmt.Errorf("invalid color mode: %s", cm.config.Display.ColorMode)
+	}
+
+	// Validate cost transition
+	if err := validateTransitionConfig(&cm.config.Display.Transition); err != nil {
+		return err
+	}
+
+	// Validate data source chain
+	seenDataSources := make(map[string]bool)
+	for _, name := range cm.config.Plugins.DataSources {
+		if seenDataSources[name] {
+			return fmt.Errorf("data source plugin %s is listed more than once", name)
+		}
+		seenDataSources[name] = true
+	}
+
+	// Validate display list
+	seenDisplays := make(map[string]bool)
+	for _, name := range cm.config.Plugins.Displays {
+		if seenDisplays[name] {
+			return fmt.Errorf("display plugin %s is listed more than once", name)
+		}
+		seenDisplays[name] = true
+	}
+
+	// Validate external plugins
+	if err := validateExternalPlugins(cm.config.Plugins.External); err != nil {
+		return err
+	}
+
+	// Validate composite data source
+	if err := validateCompositeConfig(&cm.config.Plugins.Composite); err != nil {
+		return err
+	}
+
+	if cm.config.Plugins.HealthCheckInterval < 0 {
+		return fmt.Errorf("plugin health check interval must not be negative")
+	}
+
+	// Validate dashboard layout
+	if err := validateDashboardConfig(&cm.config.Display.Dashboard); err != nil {
+		return err
+	}
+
 	// Validate display dimensions
 	if cm.config.Display.Width <= 0 || cm.config.Display.Height <= 0 {
 		return fmt.Errorf("display dimensions must be positive")
 	}
 
+	// Validate data source timeout and cache time
+	if cm.config.DataSource.Timeout <= 0 {
+		return fmt.Errorf("data source timeout must be positive")
+	}
+	if cm.config.DataSource.CacheTime < 0 {
+		return fmt.Errorf("data source cache time must not be negative")
+	}
+
 	// Validate refresh rate
 	if cm.config.App.RefreshRate <= 0 {
 		return fmt.Errorf("refresh rate must be positive")
@@ -200,9 +505,241 @@ func (cm *ConfigManager) ValidateConfig() error {
 		return fmt.Errorf("animation speed must be positive")
 	}
 
+	// Validate currency settings
+	if err := validateCurrencyConfig(&cm.config.Currency); err != nil {
+		return err
+	}
+
+	// Validate milestones
+	if err := validateMilestoneConfig(&cm.config.Milestones); err != nil {
+		return err
+	}
+
+	// Validate kiosk mode
+	if err := validateKioskConfig(&cm.config.Kiosk); err != nil {
+		return err
+	}
+
+	// Validate team sync
+	if err := validateTeamConfig(&cm.config.Team); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateCurrencyConfig validates the currency conversion settings
+func validateCurrencyConfig(config *CurrencyConfig) error {
+	if config.Display == "" {
+		return nil
+	}
+
+	if len(config.Display) != 3 {
+		return fmt.Errorf("invalid display currency: %s", config.Display)
+	}
+
+	if config.RatesFile == "" && len(config.Rates) == 0 && !strings.EqualFold(config.Display, config.RatesBase) {
+		return fmt.Errorf("no exchange rates configured for display currency %s", config.Display)
+	}
+
+	for code, rate := range config.Rates {
+		if rate <= 0 {
+			return fmt.Errorf("exchange rate for %s must be positive", code)
+		}
+	}
+
+	return nil
+}
+
+// validateTransitionConfig validates the count-up transition settings
+func validateTransitionConfig(config *domain.TransitionConfig) error {
+	if config.Duration < 0 {
+		return fmt.Errorf("transition duration must not be negative")
+	}
+
+	if !domain.IsValidEasing(config.Easing) {
+		return fmt.Errorf("invalid transition easing: %s", config.Easing)
+	}
+
+	if config.BadgeDuration < 0 {
+		return fmt.Errorf("delta badge duration must not be negative")
+	}
+
+	return nil
+}
+
+// validateExternalPlugins validates the declared external plugins
+func validateExternalPlugins(plugins []domain.ExternalPluginConfig) error {
+	for i, plugin := range plugins {
+		if plugin.Command == "" {
+			return fmt.Errorf("external plugin %d: no command", i+1)
+		}
+		if plugin.Kind != "" && !plugin.Kind.IsValid() {
+			return fmt.Errorf("external plugin %d: invalid kind: %s", i+1, plugin.Kind)
+		}
+		if plugin.Timeout < 0 {
+			return fmt.Errorf("external plugin %d: timeout must not be negative", i+1)
+		}
+	}
+
+	return nil
+}
+
+// validateCompositeConfig validates the sources of the composite data source
+func validateCompositeConfig(config *domain.CompositeConfig) error {
+	if config.Timeout < 0 {
+		return fmt.Errorf("composite timeout must not be negative")
+	}
+
+	seen := make(map[string]bool)
+	for i, source := range config.Sources {
+		if source.DataSource == "" {
+			return fmt.Errorf("composite source %d: no datasource", i+1)
+		}
+		if source.DataSource == "composite" {
+			return fmt.Errorf("composite source %d: the composite data source cannot include itself", i+1)
+		}
+		if seen[source.Name()] {
+			return fmt.Errorf("composite source %s is listed more than once, give each a distinct label", source.Name())
+		}
+		seen[source.Name()] = true
+	}
+
+	return nil
+}
+
+// validateDashboardConfig validates the dashboard layout and budget
+func validateDashboardConfig(config *domain.DashboardConfig) error {
+	if config.Budget < 0 {
+		return fmt.Errorf("dashboard budget must not be negative")
+	}
+
+	seen := make(map[domain.DashboardPane]bool)
+	for i, row := range config.Layout {
+		if row.Weight < 0 {
+			return fmt.Errorf("dashboard row %d: weight must not be negative", i+1)
+		}
+		if len(row.Columns) == 0 {
+			return fmt.Errorf("dashboard row %d: no panes", i+1)
+		}
+
+		for _, column := range row.Columns {
+			if !domain.IsValidDashboardPane(column.Pane) {
+				return fmt.Errorf("dashboard row %d: invalid pane: %s", i+1, column.Pane)
+			}
+			if column.Weight < 0 {
+				return fmt.Errorf("dashboard row %d: weight of pane %s must not be negative", i+1, column.Pane)
+			}
+			if seen[column.Pane] {
+				return fmt.Errorf("dashboard pane %s appears more than once", column.Pane)
+			}
+			seen[column.Pane] = true
+		}
+	}
+
+	return nil
+}
+
+// validateMilestoneConfig validates the milestone celebration settings
+func validateMilestoneConfig(config *domain.MilestoneConfig) error {
+	if config.Every < 0 {
+		return fmt.Errorf("milestone interval must not be negative")
+	}
+
+	for _, amount := range config.Amounts {
+		if amount <= 0 {
+			return fmt.Errorf("milestone amount must be positive: %g", amount)
+		}
+	}
+
+	if config.Duration < 0 {
+		return fmt.Errorf("celebration duration must not be negative")
+	}
+
+	return nil
+}
+
+// validateKioskConfig validates the kiosk mode settings
+func validateKioskConfig(config *domain.KioskConfig) error {
+	if config.DriftInterval < 0 || config.RotateInterval < 0 {
+		return fmt.Errorf("kiosk intervals must not be negative")
+	}
+
+	if config.DriftRange < 0 {
+		return fmt.Errorf("kiosk drift range must not be negative")
+	}
+
+	if config.RetryInterval <= 0 {
+		return fmt.Errorf("kiosk retry interval must be positive")
+	}
+
+	quiet := config.QuietHours
+	if (quiet.Start == "") != (quiet.End == "") {
+		return fmt.Errorf("quiet hours need both a start and an end")
+	}
+	for _, value := range []string{quiet.Start, quiet.End} {
+		if value == "" {
+			continue
+		}
+		if _, err := domain.ParseClockTime(value); err != nil {
+			return fmt.Errorf("invalid quiet hours: %w", err)
+		}
+	}
+
+	if quiet.Brightness < 0 || quiet.Brightness > 1 {
+		return fmt.Errorf("quiet hours brightness must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// validateTeamConfig validates the team sync settings
+func validateTeamConfig(config *domain.TeamConfig) error {
+	if config.Share && config.Dir == "" {
+		return fmt.Errorf("team sharing needs a shared directory")
+	}
+	if config.Share && config.Interval <= 0 {
+		return fmt.Errorf("team snapshot interval must be positive")
+	}
+	if config.MaxAge < 0 {
+		return fmt.Errorf("team snapshot max age must not be negative")
+	}
+	if strings.ContainsAny(config.Member, `/\`) {
+		return fmt.Errorf("team member name must not contain path separators: %s", config.Member)
+	}
+	switch config.DataSource {
+	case "shared-dir", "composite":
+		return fmt.Errorf("team snapshots must come from a local data source, not %s", config.DataSource)
+	}
+
+	return nil
+}
+
+// GetTeamConfig returns the team sync settings
+func (cm *ConfigManager) GetTeamConfig() domain.TeamConfig {
+	return cm.config.Team
+}
+
+// GetKioskConfig returns the kiosk mode settings
+func (cm *ConfigManager) GetKioskConfig() domain.KioskConfig {
+	return cm.config.Kiosk
+}
+
+// GetMilestoneConfig returns the milestone celebration settings
+func (cm *ConfigManager) GetMilestoneConfig() domain.MilestoneConfig {
+	return cm.config.Milestones
+}
+
+// GetTransitionConfig returns the count-up transition settings
+func (cm *ConfigManager) GetTransitionConfig() domain.TransitionConfig {
+	return cm.config.Display.Transition
+}
+
+// SetColorProfile sets the color profile detected for the output terminal
+func (cm *ConfigManager) SetColorProfile(profile domain.ColorProfile) {
+	cm.config.Display.ColorProfile = profile
+}
+
 // ApplyFlagsToConfig applies command line flag values to configuration
 func (cm *ConfigManager) ApplyFlagsToConfig(flagConfig *FlagConfig) error {
 	if cm.config == nil {
@@ -222,6 +759,36 @@ func (cm *ConfigManager) ApplyFlagsToConfig(flagConfig *FlagConfig) error {
 		cm.config.Animation.Enabled = *flagConfig.Animation.Enabled
 	}
 
+	if flagConfig.Animation.Theme != "" {
+		if err := cm.SetTheme(flagConfig.Animation.Theme); err != nil {
+			return err
+		}
+	}
+
+	if flagConfig.SaveSettings {
+		cm.config.App.SaveSettingsOnExit = true
+	}
+
+	// Apply display configuration from flags
+	if flagConfig.ColorMode != "" {
+		cm.config.Display.ColorMode = flagConfig.ColorMode
+	}
+
+	// Apply kiosk mode from flags
+	if flagConfig.Kiosk {
+		cm.config.Kiosk.Enabled = true
+	}
+
+	// Apply display plugin from flags
+	if flagConfig.Display != "" {
+		cm.config.Plugins.Display = flagConfig.Display
+	}
+
+	// Apply currency configuration from flags
+	if flagConfig.Currency != "" {
+		cm.config.Currency.Display = strings.ToUpper(flagConfig.Currency)
+	}
+
 	// Apply bankruptcy mode (note: this affects datasource configuration)
 	// Bankruptcy mode is handled by the main application, not by configuration
 
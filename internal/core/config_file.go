@@ -0,0 +1,191 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UpdateConfigFile sets dotted keys (e.g. "animation.speed") in a YAML config file.
+// The rest of the file, including comments, is preserved; missing files and
+// intermediate sections are created.
+func UpdateConfigFile(path string, updates map[string]interface{}) error {
+	var document yaml.Node
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config file '%s': %w", path, err)
+	}
+	if len(bytes.TrimSpace(content)) > 0 {
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return fmt.Errorf("failed to parse config file '%s': %w", path, err)
+		}
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file '%s' must contain a mapping", path)
+	}
+
+	// Apply updates in a stable order so new keys are written deterministically
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var value yaml.Node
+		if err := value.Encode(updates[key]); err != nil {
+			return fmt.Errorf("failed to encode config value for '%s': %w", key, err)
+		}
+		if err := setMappingValue(root, strings.Split(key, "."), &value); err != nil {
+			return fmt.Errorf("failed to set config key '%s': %w", key, err)
+		}
+	}
+
+	var output bytes.Buffer
+	encoder := yaml.NewEncoder(&output)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, output.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// setMappingValue sets the value at the key path within a mapping node
+func setMappingValue(mapping *yaml.Node, path []string, value *yaml.Node) error {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			// Keep comments attached to the existing value
+			value.HeadComment = mapping.Content[i+1].HeadComment
+			value.LineComment = mapping.Content[i+1].LineComment
+			mapping.Content[i+1] = value
+			return nil
+		}
+
+		child := mapping.Content[i+1]
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("'%s' is not a section", path[0])
+		}
+		return setMappingValue(child, path[1:], value)
+	}
+
+	// Key not found: append it, creating intermediate sections as needed
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		mapping.Content = append(mapping.Content, key, value)
+		return nil
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, key, child)
+	return setMappingValue(child, path[1:], value)
+}
+
+// SaveAnimationSettings writes the current animation settings to the config file
+func (cm *ConfigManager) SaveAnimationSettings() error {
+	path, err := cm.savePath()
+	if err != nil {
+		return err
+	}
+
+	animation := cm.config.Animation
+	updates := map[string]interface{}{
+		"animation.enabled": animation.Enabled,
+		"animation.speed":   animation.Speed.String(),
+		"animation.pattern": string(animation.Pattern),
+	}
+	if animation.Theme != "" {
+		updates["animation.theme"] = animation.Theme
+	} else {
+		updates["animation.colors"] = animation.Colors
+	}
+
+	if err := UpdateConfigFile(path, updates); err != nil {
+		return err
+	}
+
+	cm.configPath = path
+	return nil
+}
+
+// SetPluginEnabled enables or disables a plugin and saves the choice to the config file.
+// Plugins selected as one of the data sources, the animation or one of the displays cannot be disabled.
+func (cm *ConfigManager) SetPluginEnabled(name string, enabled bool) error {
+	plugins := cm.config.Plugins
+	if !enabled {
+		switch {
+		case slices.Contains(cm.DataSourceNames(), name):
+			return fmt.Errorf("plugin '%s' is a selected data source, remove it from the data sources first", name)
+		case name == plugins.Animation:
+			return fmt.Errorf("plugin '%s' is the selected animation, choose another one first", name)
+		case slices.Contains(cm.DisplayNames(), name):
+			return fmt.Errorf("plugin '%s' is a selected display, remove it from the displays first", name)
+		}
+	}
+
+	disabled := slices.DeleteFunc(slices.Clone(plugins.Disabled), func(disabledName string) bool {
+		return disabledName == name
+	})
+	if !enabled {
+		disabled = append(disabled, name)
+	}
+	if disabled == nil {
+		disabled = []string{}
+	}
+
+	path, err := cm.savePath()
+	if err != nil {
+		return err
+	}
+	if err := UpdateConfigFile(path, map[string]interface{}{"plugins.disabled": disabled}); err != nil {
+		return err
+	}
+
+	cm.config.Plugins.Disabled = disabled
+	cm.configPath = path
+	return nil
+}
+
+// savePath returns the config file that settings are saved to
+func (cm *ConfigManager) savePath() (string, error) {
+	path := cm.configPath
+	if path == "" {
+		path = DefaultConfigPath()
+	}
+	if path == "" {
+		return "", fmt.Errorf("no config file location available")
+	}
+	return path, nil
+}
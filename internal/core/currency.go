@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// CurrencyConverter converts cost data into the configured display currency
+type CurrencyConverter struct {
+	provider interfaces.RateProvider
+	target   string
+}
+
+// NewCurrencyConverter creates a converter using the given rate provider
+func NewCurrencyConverter(provider interfaces.RateProvider, target string) *CurrencyConverter {
+	return &CurrencyConverter{
+		provider: provider,
+		target:   strings.ToUpper(target),
+	}
+}
+
+// TargetCurrency returns the currency cost data is converted into
+func (c *CurrencyConverter) TargetCurrency() string {
+	return c.target
+}
+
+// Convert returns the cost data expressed in the target currency
+func (c *CurrencyConverter) Convert(ctx context.Context, data *domain.CostData) (*domain.CostData, error) {
+	if data == nil || c.target == "" {
+		return data, nil
+	}
+
+	source := data.Currency
+	if source == "" {
+		source = domain.DefaultCurrency
+	}
+	if strings.EqualFold(source, c.target) {
+		return data, nil
+	}
+
+	rates, err := c.provider.GetRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exchange rates from %s provider: %w", c.provider.Name(), err)
+	}
+
+	converted, err := domain.ConvertCostData(data, rates, c.target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert cost data to %s: %w", c.target, err)
+	}
+
+	return converted, nil
+}
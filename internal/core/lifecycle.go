@@ -0,0 +1,213 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// Health check settings
+const (
+	FailureThreshold   = 3               // Consecutive failures after which a plugin is failed
+	healthCheckTimeout = 5 * time.Second // Limit for each health probe
+)
+
+// pluginKey identifies a registered plugin, since plugins of different kinds may share a name
+func pluginKey(kind domain.PluginKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+// track starts tracking the lifecycle of a newly registered plugin. The caller holds the lock.
+func (pr *PluginRegistry) track(kind domain.PluginKind, name string) {
+	pr.states[pluginKey(kind, name)] = &domain.PluginStatus{
+		Name:  name,
+		Kind:  kind,
+		State: domain.PluginStateRegistered,
+		Since: time.Now(),
+	}
+	pr.order[kind] = append(pr.order[kind], name)
+}
+
+// stopped marks a plugin as shut down. The caller holds the lock.
+func (pr *PluginRegistry) stopped(kind domain.PluginKind, name string) {
+	if status, exists := pr.states[pluginKey(kind, name)]; exists {
+		status.State = domain.PluginStateStopped
+		status.Since = time.Now()
+	}
+}
+
+// setState moves a plugin to a new lifecycle state
+func (pr *PluginRegistry) setState(plugin interfaces.Plugin, state domain.PluginState, err error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	status, exists := pr.states[pluginKey(PluginKindOf(plugin), plugin.Name())]
+	if !exists {
+		return
+	}
+
+	if status.State != state {
+		status.State = state
+		status.Since = time.Now()
+	}
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	}
+	if state == domain.PluginStateReady {
+		status.Failures = 0
+	}
+}
+
+// RecordResult updates the state of a plugin after a call or health check. A failure
+// degrades the plugin and repeated failures fail it; a success makes it ready again.
+func (pr *PluginRegistry) RecordResult(plugin interfaces.Plugin, err error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	status, exists := pr.states[pluginKey(PluginKindOf(plugin), plugin.Name())]
+	if !exists || status.State == domain.PluginStateStopped {
+		return
+	}
+
+	state := domain.PluginStateReady
+	if err != nil {
+		status.Failures++
+		status.LastError = err.Error()
+		state = domain.PluginStateDegraded
+		if status.Failures >= FailureThreshold {
+			state = domain.PluginStateFailed
+		}
+	} else {
+		status.Failures = 0
+		status.LastError = ""
+	}
+
+	if status.State != state {
+		status.State = state
+		status.Since = time.Now()
+	}
+}
+
+// PluginState returns the lifecycle state of a registered plugin
+func (pr *PluginRegistry) PluginState(plugin interfaces.Plugin) domain.PluginState {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
+	if status, exists := pr.states[pluginKey(PluginKindOf(plugin), plugin.Name())]; exists {
+		return status.State
+	}
+	return ""
+}
+
+// PluginStatuses returns the lifecycle state of every plugin, by kind in registration order
+func (pr *PluginRegistry) PluginStatuses() []domain.PluginStatus {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
+	var statuses []domain.PluginStatus
+	for _, kind := range []domain.PluginKind{domain.PluginKindDataSource, domain.PluginKindDisplay, domain.PluginKindAnimation} {
+		for _, name := range pr.order[kind] {
+			statuses = append(statuses, *pr.states[pluginKey(kind, name)])
+		}
+	}
+	return statuses
+}
+
+// InitializeAll initializes every registered plugin. A plugin that fails to
+// initialize is marked failed without stopping the others; the failures are returned together.
+func (pr *PluginRegistry) InitializeAll() error {
+	var errs []error
+	for _, plugin := range pr.ListPlugins() {
+		if err := pr.InitializePlugin(plugin); err != nil {
+			errs = append(errs, fmt.Errorf("failed to initialize plugin '%s': %w", plugin.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckHealth probes the plugins that implement HealthChecker and records the results.
+// Failed plugins that were never initialized are initialized again once they are healthy.
+// It stops when the context is done, so that no plugin is initialized during shutdown.
+func (pr *PluginRegistry) CheckHealth(ctx context.Context) {
+	for _, plugin := range pr.ListPlugins() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		checker, ok := plugin.(interfaces.HealthChecker)
+		if !ok {
+			continue
+		}
+
+		state := pr.PluginState(plugin)
+		if !state.IsUsable() && state != domain.PluginStateFailed {
+			continue
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := checker.HealthCheck(probeCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil && !plugin.IsEnabled() {
+			_ = pr.InitializePlugin(plugin)
+			continue
+		}
+		pr.RecordResult(plugin, err)
+	}
+}
+
+// RunHealthChecks probes the plugins at the given interval until the context is done
+func (pr *PluginRegistry) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pr.CheckHealth(ctx)
+		}
+	}
+}
+
+// fallback returns the name of a plugin of the kind to use instead of the named one.
+// The named plugin is kept unless it failed or stopped and a usable alternative exists,
+// preferring ready plugins and then the given order. Data sources only fall back to
+// the preferred ones, as another source may report a different cost altogether.
+func (pr *PluginRegistry) fallback(kind domain.PluginKind, name string, preferred []string) string {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+
+	if status, exists := pr.states[pluginKey(kind, name)]; !exists ||
+		(status.State != domain.PluginStateFailed && status.State != domain.PluginStateStopped) {
+		return name
+	}
+
+	candidates := slices.Clone(preferred)
+	if kind != domain.PluginKindDataSource {
+		candidates = append(candidates, pr.order[kind]...)
+	}
+	for _, state := range []domain.PluginState{domain.PluginStateReady, domain.PluginStateDegraded} {
+		for _, candidate := range candidates {
+			if status, exists := pr.states[pluginKey(kind, candidate)]; exists && status.State == state {
+				return candidate
+			}
+		}
+	}
+
+	return name
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
 )
 
 // PluginRegistry implements the plugin registry interface
@@ -14,6 +15,11 @@ type PluginRegistry struct {
 	displays      map[string]interfaces.DisplayPlugin
 	animations    map[string]interfaces.AnimationPlugin
 	configManager *ConfigManager
+
+	states map[string]*domain.PluginStatus // Lifecycle of each plugin by kind and name
+	order  map[domain.PluginKind][]string  // Plugin names in registration order, for fallbacks
+
+	lastFetch domain.FetchReport // Data source that served the latest cost data
 }
 
 // NewPluginRegistry creates a new plugin registry
@@ -23,6 +29,8 @@ func NewPluginRegistry(configManager *ConfigManager) *PluginRegistry {
 		displays:      make(map[string]interfaces.DisplayPlugin),
 		animations:    make(map[string]interfaces.AnimationPlugin),
 		configManager: configManager,
+		states:        make(map[string]*domain.PluginStatus),
+		order:         make(map[domain.PluginKind][]string),
 	}
 }
 
@@ -37,6 +45,7 @@ func (pr *PluginRegistry) RegisterDataSource(plugin interfaces.DataSourcePlugin)
 	}
 
 	pr.dataSources[name] = plugin
+	pr.track(domain.PluginKindDataSource, name)
 	return nil
 }
 
@@ -51,6 +60,7 @@ func (pr *PluginRegistry) RegisterDisplay(plugin interfaces.DisplayPlugin) error
 	}
 
 	pr.displays[name] = plugin
+	pr.track(domain.PluginKindDisplay, name)
 	return nil
 }
 
@@ -65,6 +75,7 @@ func (pr *PluginRegistry) RegisterAnimation(plugin interfaces.AnimationPlugin) e
 	}
 
 	pr.animations[name] = plugin
+	pr.track(domain.PluginKindAnimation, name)
 	return nil
 }
 
@@ -141,6 +152,7 @@ func (pr *PluginRegistry) ShutdownAll() error {
 		if err := plugin.Shutdown(); err != nil {
 			errors = append(errors, fmt.Errorf("failed to shutdown data source plugin '%s': %w", name, err))
 		}
+		pr.stopped(domain.PluginKindDataSource, name)
 	}
 
 	// Shutdown display plugins
@@ -148,6 +160,7 @@ func (pr *PluginRegistry) ShutdownAll() error {
 		if err := plugin.Shutdown(); err != nil {
 			errors = append(errors, fmt.Errorf("failed to shutdown display plugin '%s': %w", name, err))
 		}
+		pr.stopped(domain.PluginKindDisplay, name)
 	}
 
 	// Shutdown animation plugins
@@ -155,6 +168,7 @@ func (pr *PluginRegistry) ShutdownAll() error {
 		if err := plugin.Shutdown(); err != nil {
 			errors = append(errors, fmt.Errorf("failed to shutdown animation plugin '%s': %w", name, err))
 		}
+		pr.stopped(domain.PluginKindAnimation, name)
 	}
 
 	if len(errors) > 0 {
@@ -164,41 +178,94 @@ func (pr *PluginRegistry) ShutdownAll() error {
 	return nil
 }
 
-// GetActiveDataSource returns the active data source plugin based on config
+// GetActiveDataSource returns the first data source based on config, or
+// another data source when it has failed, preferring the configured ones
 func (pr *PluginRegistry) GetActiveDataSource() (interfaces.DataSourcePlugin, error) {
 	config := pr.configManager.GetConfig()
 	if config == nil {
 		return nil, fmt.Errorf("no configuration available")
 	}
 
-	return pr.GetDataSource(config.Plugins.DataSource)
+	// Data sources missing from the chain are skipped, like when fetching
+	names := pr.configManager.DataSourceNames()
+	name := config.Plugins.DataSource
+	for _, candidate := range names {
+		if _, err := pr.GetDataSource(candidate); err == nil {
+			name = candidate
+			break
+		}
+	}
+
+	return pr.GetDataSource(pr.fallback(domain.PluginKindDataSource, name, names))
 }
 
-// GetActiveDisplay returns the active display plugin based on config
+// GetActiveDisplay returns the active display plugin based on config, or another
+// display when it has failed, preferring the configured displays
 func (pr *PluginRegistry) GetActiveDisplay() (interfaces.DisplayPlugin, error) {
 	config := pr.configManager.GetConfig()
 	if config == nil {
 		return nil, fmt.Errorf("no configuration available")
 	}
 
-	return pr.GetDisplay(config.Plugins.Display)
+	return pr.GetDisplay(pr.fallback(domain.PluginKindDisplay, config.Plugins.Display, pr.configManager.DisplayNames()))
+}
+
+// GetActiveDisplays returns the display plugins to switch between, in order
+func (pr *PluginRegistry) GetActiveDisplays() ([]interfaces.DisplayPlugin, error) {
+	if pr.configManager.GetConfig() == nil {
+		return nil, fmt.Errorf("no configuration available")
+	}
+
+	names := pr.configManager.DisplayNames()
+	displays := make([]interfaces.DisplayPlugin, len(names))
+	for i, name := range names {
+		display, err := pr.GetDisplay(name)
+		if err != nil {
+			return nil, err
+		}
+		displays[i] = display
+	}
+
+	return displays, nil
+}
+
+// SetActiveDisplay switches the active display plugin
+func (pr *PluginRegistry) SetActiveDisplay(name string) error {
+	config := pr.configManager.GetConfig()
+	if config == nil {
+		return fmt.Errorf("no configuration available")
+	}
+
+	if _, err := pr.GetDisplay(name); err != nil {
+		return err
+	}
+
+	config.Plugins.Display = name
+	return nil
 }
 
-// GetActiveAnimation returns the active animation plugin based on config
+// GetActiveAnimation returns the active animation plugin based on config,
+// or another animation when it has failed
 func (pr *PluginRegistry) GetActiveAnimation() (interfaces.AnimationPlugin, error) {
 	config := pr.configManager.GetConfig()
 	if config == nil {
 		return nil, fmt.Errorf("no configuration available")
 	}
 
-	return pr.GetAnimation(config.Plugins.Animation)
+	return pr.GetAnimation(pr.fallback(domain.PluginKindAnimation, config.Plugins.Animation, nil))
 }
 
-// InitializePlugin initializes a plugin with its configuration
+// InitializePlugin initializes a plugin with its configuration, marking it ready or failed
 func (pr *PluginRegistry) InitializePlugin(plugin interfaces.Plugin) error {
-	// Initialize with empty config since we no longer load plugin-specific configuration
-	pluginConfig := make(map[string]interface{})
-	return plugin.Initialize(pluginConfig)
+	pr.setState(plugin, domain.PluginStateInitializing, nil)
+
+	if err := plugin.Initialize(pr.configManager.PluginConfig(plugin.Name())); err != nil {
+		pr.setState(plugin, domain.PluginStateFailed, err)
+		return err
+	}
+
+	pr.setState(plugin, domain.PluginStateReady, nil)
+	return nil
 }
 
 // GetPluginCount returns the number of registered plugins by type
@@ -208,3 +275,31 @@ func (pr *PluginRegistry) GetPluginCount() (dataSources, displays, animations in
 
 	return len(pr.dataSources), len(pr.displays), len(pr.animations)
 }
+
+// Register registers a plugin according to the interfaces it implements
+func (pr *PluginRegistry) Register(plugin interfaces.Plugin) error {
+	switch p := plugin.(type) {
+	case interfaces.DataSourcePlugin:
+		return pr.RegisterDataSource(p)
+	case interfaces.DisplayPlugin:
+		return pr.RegisterDisplay(p)
+	case interfaces.AnimationPlugin:
+		return pr.RegisterAnimation(p)
+	default:
+		return fmt.Errorf("plugin '%s' is not a data source, display or animation", plugin.Name())
+	}
+}
+
+// PluginKindOf returns what a plugin provides, or an empty kind for unknown plugins
+func PluginKindOf(plugin interfaces.Plugin) domain.PluginKind {
+	switch plugin.(type) {
+	case interfaces.DataSourcePlugin:
+		return domain.PluginKindDataSource
+	case interfaces.DisplayPlugin:
+		return domain.PluginKindDisplay
+	case interfaces.AnimationPlugin:
+		return domain.PluginKindAnimation
+	default:
+		return ""
+	}
+}
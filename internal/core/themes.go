@@ -0,0 +1,176 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// DefaultThemeName is the theme whose palette is used by default
+const DefaultThemeName = "rainbow"
+
+// builtinThemes lists the palettes shipped with ccugorg
+var builtinThemes = []domain.Theme{
+	{
+		Name:        "rainbow",
+		Description: "Classic 12-step rainbow",
+		Colors: []string{
+			"#FF0000", // Red
+			"#FF8000", // Orange
+			"#FFFF00", // Yellow
+			"#80FF00", // Light Green
+			"#00FF00", // Green
+			"#00FF80", // Cyan Green
+			"#00FFFF", // Cyan
+			"#0080FF", // Light Blue
+			"#0000FF", // Blue
+			"#8000FF", // Purple
+			"#FF00FF", // Magenta
+			"#FF0080", // Pink
+		},
+	},
+	{
+		Name:        "pastel",
+		Description: "Soft pastel tones",
+		Colors:      []string{"#FFB3BA", "#FFDFBA", "#FFFFBA", "#BAFFC9", "#BAE1FF", "#D7BAFF", "#FFBAF2"},
+	},
+	{
+		Name:        "neon",
+		Description: "Saturated neon signs",
+		Colors:      []string{"#FF073A", "#FF9F1C", "#FFFF33", "#39FF14", "#00FFFF", "#1F51FF", "#BC13FE", "#FF10F0"},
+	},
+	{
+		Name:        "monochrome",
+		Description: "Shades of gray",
+		Colors:      []string{"#FFFFFF", "#E0E0E0", "#C0C0C0", "#A0A0A0", "#808080", "#A0A0A0", "#C0C0C0", "#E0E0E0"},
+	},
+	{
+		Name:        "solarized",
+		Description: "Solarized accent colors",
+		Colors:      []string{"#B58900", "#CB4B16", "#DC322F", "#D33682", "#6C71C4", "#268BD2", "#2AA198", "#859900"},
+	},
+	{
+		Name:        "dracula",
+		Description: "Dracula accent colors",
+		Colors:      []string{"#FF5555", "#FFB86C", "#F1FA8C", "#50FA7B", "#8BE9FD", "#BD93F9", "#FF79C6"},
+	},
+	{
+		Name:        "okabe-ito",
+		Description: "Colorblind-safe Okabe-Ito palette",
+		Colors:      []string{"#E69F00", "#56B4E9", "#009E73", "#F0E442", "#0072B2", "#D55E00", "#CC79A7"},
+	},
+	{
+		Name:        "viridis",
+		Description: "Colorblind-safe perceptually uniform viridis scale",
+		Colors:      []string{"#440154", "#482878", "#3E4A89", "#31688E", "#26828E", "#1F9E89", "#35B779", "#6DCD59", "#B4DE2C", "#FDE725"},
+	},
+	{
+		Name:        "trans",
+		Description: "Transgender pride flag",
+		Colors:      []string{"#5BCEFA", "#F5A9B8", "#FFFFFF", "#F5A9B8", "#5BCEFA"},
+	},
+	{
+		Name:        "pride",
+		Description: "Six-stripe pride flag",
+		Colors:      []string{"#E40303", "#FF8C00", "#FFED00", "#008026", "#004DFF", "#750787"},
+	},
+	{
+		Name:        "bi",
+		Description: "Bisexual pride flag",
+		Colors:      []string{"#D60270", "#D60270", "#9B4F96", "#0038A8", "#0038A8"},
+	},
+}
+
+// BuiltinThemes returns a copy of the built-in themes
+func BuiltinThemes() []domain.Theme {
+	themes := make([]domain.Theme, len(builtinThemes))
+	for i, theme := range builtinThemes {
+		themes[i] = copyTheme(theme)
+	}
+	return themes
+}
+
+// defaultThemeColors returns a copy of the default theme palette
+func defaultThemeColors() []string {
+	return copyTheme(builtinThemes[0]).Colors
+}
+
+// copyTheme returns a theme with its own copy of the palette
+func copyTheme(theme domain.Theme) domain.Theme {
+	theme.Colors = append([]string(nil), theme.Colors...)
+	return theme
+}
+
+// ListThemes returns the built-in themes followed by user-defined themes.
+// A user-defined theme with the name of a built-in theme replaces it.
+func (cm *ConfigManager) ListThemes() []domain.Theme {
+	themes := BuiltinThemes()
+	for _, userTheme := range cm.config.Themes {
+		replaced := false
+		for i, theme := range themes {
+			if strings.EqualFold(theme.Name, userTheme.Name) {
+				themes[i] = copyTheme(userTheme)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			themes = append(themes, copyTheme(userTheme))
+		}
+	}
+	return themes
+}
+
+// GetTheme returns the theme with the given name
+func (cm *ConfigManager) GetTheme(name string) (domain.Theme, error) {
+	themes := cm.ListThemes()
+	for _, theme := range themes {
+		if strings.EqualFold(theme.Name, name) {
+			return theme, nil
+		}
+	}
+
+	names := make([]string, len(themes))
+	for i, theme := range themes {
+		names[i] = theme.Name
+	}
+	return domain.Theme{}, fmt.Errorf("unknown theme '%s'. Available themes: %s", name, strings.Join(names, ", "))
+}
+
+// SetTheme applies the palette of the named theme to the animation configuration
+func (cm *ConfigManager) SetTheme(name string) error {
+	theme, err := cm.GetTheme(name)
+	if err != nil {
+		return err
+	}
+
+	cm.config.Animation.Theme = theme.Name
+	cm.config.Animation.Colors = theme.Colors
+	return nil
+}
+
+// ValidateThemes validates every theme palette against the animation plugin
+func (cm *ConfigManager) ValidateThemes(plugin interfaces.AnimationPlugin) error {
+	for _, theme := range cm.ListThemes() {
+		if err := ValidateTheme(theme, cm.GetAnimationConfig(), plugin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTheme validates a theme palette by substituting it into an animation configuration
+func ValidateTheme(theme domain.Theme, base *domain.AnimationConfig, plugin interfaces.AnimationPlugin) error {
+	if theme.Name == "" {
+		return fmt.Errorf("theme name cannot be empty")
+	}
+
+	config := *base
+	config.Colors = theme.Colors
+	if err := plugin.ValidateAnimationConfig(&config); err != nil {
+		return fmt.Errorf("invalid theme '%s': %w", theme.Name, err)
+	}
+	return nil
+}
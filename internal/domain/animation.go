@@ -10,6 +10,7 @@ type AnimationConfig struct {
 	Colors  []string         `json:"colors"`
 	Enabled bool             `json:"enabled"`
 	Pattern AnimationPattern `json:"pattern"`
+	Theme   string           `json:"theme,omitempty"`
 }
 
 // AnimationPattern defines the type of animation pattern
@@ -1,17 +1,171 @@
 package domain
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
 // CostData represents the cost information from ccusage
 type CostData struct {
-	TotalCost      float64            `json:"total_cost"`
-	Currency       string             `json:"currency"`
-	Timestamp      time.Time          `json:"timestamp"`
+	TotalCost      float64             `json:"total_cost"`
+	Currency       string              `json:"currency"`
+	Timestamp      time.Time           `json:"timestamp"`
+	ModelBreakdown map[string]float64  `json:"model_breakdown,omitempty"`
+	Tokens         TokenUsage          `json:"tokens"`
+	Daily          []DailyCost         `json:"daily,omitempty"` // Oldest first
+	Conversion     *CurrencyConversion `json:"conversion,omitempty"`
+	Contributors   []Contribution      `json:"contributors,omitempty"` // Set when the cost adds up several sources
+	Stale          bool                `json:"-"`                      // Served from a cache while fresh data is loaded
+}
+
+// CachedCost is cost data kept on disk to be shown at the next startup
+type CachedCost struct {
+	SavedAt time.Time `json:"saved_at"`
+	Source  string    `json:"source,omitempty"` // Data source that served the data
+	Cost    *CostData `json:"cost"`
+}
+
+// IsStale reports whether the cached data is older than ttl at now
+func (c CachedCost) IsStale(now time.Time, ttl time.Duration) bool {
+	return now.Sub(c.SavedAt) >= ttl
+}
+
+// Contribution is the share of combined cost data that came from one source
+type Contribution struct {
+	Label  string     `json:"label"`
+	Cost   float64    `json:"cost"`
+	Tokens TokenUsage `json:"tokens"`
+	Error  string     `json:"error,omitempty"` // Why the source contributed nothing
+}
+
+// FetchReport tells which data source of the fallback chain served cost data
+type FetchReport struct {
+	Source string `json:"source"`           // Data source that served the data, empty when none could
+	Reason string `json:"reason,omitempty"` // Why the data sources tried before it were passed over
+}
+
+// IsFallback reports whether data sources were passed over before one served the data
+func (r FetchReport) IsFallback() bool {
+	return r.Reason != ""
+}
+
+// SourceCost is the outcome of fetching cost data from one of several sources
+type SourceCost struct {
+	Label string
+	Data  *CostData
+	Err   error
+}
+
+// CombineCostData adds up the cost data of several sources into one, listing the share
+// of each source as a contribution. Sources that failed or report another currency than
+// the first one are listed with their error. The result is stale when the data of any
+// contributing source is. It returns nil when no source has data.
+func CombineCostData(sources []SourceCost) *CostData {
+	var combined *CostData
+	days := make(map[string]*DailyCost)
+	contributors := make([]Contribution, 0, len(sources))
+
+	for _, source := range sources {
+		contribution := Contribution{Label: source.Label}
+		data := source.Data
+		switch {
+		case source.Err != nil:
+			contribution.Error = source.Err.Error()
+		case data == nil:
+			contribution.Error = "no cost data"
+		case combined != nil && !strings.EqualFold(currencyOf(data), combined.Currency):
+			contribution.Error = fmt.Sprintf("reports %s, not %s", currencyOf(data), combined.Currency)
+		}
+		if contribution.Error != "" {
+			contributors = append(contributors, contribution)
+			continue
+		}
+
+		if combined == nil {
+			combined = &CostData{Currency: currencyOf(data), ModelBreakdown: make(map[string]float64)}
+		}
+
+		contribution.Cost = data.TotalCost
+		contribution.Tokens = data.Tokens
+		contributors = append(contributors, contribution)
+
+		combined.TotalCost += data.TotalCost
+		combined.Stale = combined.Stale || data.Stale
+		combined.Tokens = addTokens(combined.Tokens, data.Tokens)
+		if data.Timestamp.After(combined.Timestamp) {
+			combined.Timestamp = data.Timestamp
+		}
+		addBreakdown(combined.ModelBreakdown, data.ModelBreakdown)
+
+		for _, day := range data.Daily {
+			key := day.Date.Format("2006-01-02")
+			merged, exists := days[key]
+			if !exists {
+				merged = &DailyCost{Date: day.Date, ModelBreakdown: make(map[string]float64)}
+				days[key] = merged
+			}
+			merged.Cost += day.Cost
+			merged.Tokens = addTokens(merged.Tokens, day.Tokens)
+			addBreakdown(merged.ModelBreakdown, day.ModelBreakdown)
+		}
+	}
+
+	if combined == nil {
+		return nil
+	}
+
+	for _, day := range days {
+		combined.Daily = append(combined.Daily, *day)
+	}
+	sort.Slice(combined.Daily, func(i, j int) bool {
+		return combined.Daily[i].Date.Before(combined.Daily[j].Date)
+	})
+	combined.Contributors = contributors
+
+	return combined
+}
+
+// currencyOf returns the currency of cost data, the default currency when unset
+func currencyOf(data *CostData) string {
+	if data.Currency == "" {
+		return DefaultCurrency
+	}
+	return strings.ToUpper(data.Currency)
+}
+
+// addTokens returns the sum of two token counts
+func addTokens(a, b TokenUsage) TokenUsage {
+	return TokenUsage{Input: a.Input + b.Input, Output: a.Output + b.Output}
+}
+
+// addBreakdown adds the per-model costs of from to into
+func addBreakdown(into, from map[string]float64) {
+	for model, cost := range from {
+		into[model] += cost
+	}
+}
+
+// DailyCost represents the cost of a single day
+type DailyCost struct {
+	Date           time.Time          `json:"date"`
+	Cost           float64            `json:"cost"`
+	Tokens         TokenUsage         `json:"tokens"`
 	ModelBreakdown map[string]float64 `json:"model_breakdown,omitempty"`
 }
 
+// TokenUsage represents the number of tokens used
+type TokenUsage struct {
+	Input  int `json:"input"`
+	Output int `json:"output"`
+}
+
+// Total returns the sum of input and output tokens
+func (t TokenUsage) Total() int {
+	return t.Input + t.Output
+}
+
 // CostDataRepository defines the interface for fetching cost data
 type CostDataRepository interface {
 	FetchCostData() (*CostData, error)
@@ -0,0 +1,51 @@
+package domain
+
+import "strings"
+
+// SymbolPosition defines where a currency symbol is placed relative to the amount
+type SymbolPosition string
+
+const (
+	SymbolPrefix SymbolPosition = "prefix"
+	SymbolSuffix SymbolPosition = "suffix"
+)
+
+// DefaultCurrency is the currency assumed when cost data does not specify one
+const DefaultCurrency = "USD"
+
+// Currency describes how amounts in a given currency are presented
+type Currency struct {
+	Code     string         `json:"code"`
+	Symbol   string         `json:"symbol"`
+	Decimals int            `json:"decimals"`
+	Position SymbolPosition `json:"position"`
+}
+
+// knownCurrencies lists the currencies with dedicated symbols and glyphs
+var knownCurrencies = map[string]Currency{
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2, Position: SymbolPrefix},
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2, Position: SymbolPrefix},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0, Position: SymbolPrefix},
+	"GBP": {Code: "GBP", Symbol: "£", Decimals: 2, Position: SymbolPrefix},
+}
+
+// LookupCurrency returns the presentation details for a currency code.
+// Unknown codes fall back to the code itself placed after the amount.
+func LookupCurrency(code string) Currency {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		code = DefaultCurrency
+	}
+
+	if currency, exists := knownCurrencies[code]; exists {
+		return currency
+	}
+
+	return Currency{Code: code, Symbol: code, Decimals: 2, Position: SymbolSuffix}
+}
+
+// IsKnownCurrency reports whether the currency code has a dedicated symbol
+func IsKnownCurrency(code string) bool {
+	_, exists := knownCurrencies[strings.ToUpper(strings.TrimSpace(code))]
+	return exists
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+)
+
+// DashboardPane identifies a pane of the dashboard display
+type DashboardPane string
+
+const (
+	PaneTotal  DashboardPane = "total"  // Big total drawn as rainbow art
+	PaneModels DashboardPane = "models" // Per-model cost table
+	PaneDaily  DashboardPane = "daily"  // Daily cost bar chart
+	PaneTokens DashboardPane = "tokens" // Token usage summary
+	PaneBudget DashboardPane = "budget" // Monthly budget gauge
+	PaneStatus DashboardPane = "status" // One-line status footer
+)
+
+// DashboardPanes returns the panes in collapse priority order: when the
+// terminal is too small, panes are dropped from the end of the list first
+func DashboardPanes() []DashboardPane {
+	return []DashboardPane{PaneTotal, PaneStatus, PaneBudget, PaneModels, PaneDaily, PaneTokens}
+}
+
+// IsValidDashboardPane reports whether the pane is known
+func IsValidDashboardPane(pane DashboardPane) bool {
+	for _, known := range DashboardPanes() {
+		if pane == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DashboardColumn is a pane within a dashboard row
+type DashboardColumn struct {
+	Pane   DashboardPane `json:"pane" yaml:"pane"`
+	Weight int           `json:"weight" yaml:"weight"` // Share of the row width, 0 counts as 1
+}
+
+// DashboardRow is a horizontal band of panes
+type DashboardRow struct {
+	Weight  int               `json:"weight" yaml:"weight"` // Share of the height, 0 counts as 1
+	Columns []DashboardColumn `json:"columns" yaml:"columns"`
+}
+
+// DashboardConfig defines the dashboard layout and its budget
+type DashboardConfig struct {
+	Layout []DashboardRow `json:"layout" yaml:"layout"`
+	Budget float64        `json:"budget" yaml:"budget"` // Monthly budget in the display currency, 0 hides the gauge
+}
+
+// DefaultDashboardConfig returns the default dashboard layout
+func DefaultDashboardConfig() DashboardConfig {
+	return DashboardConfig{
+		Layout: []DashboardRow{
+			{Weight: 3, Columns: []DashboardColumn{{Pane: PaneTotal, Weight: 3}, {Pane: PaneTokens, Weight: 1}}},
+			{Weight: 2, Columns: []DashboardColumn{{Pane: PaneModels}, {Pane: PaneDaily}, {Pane: PaneBudget}}},
+			{Columns: []DashboardColumn{{Pane: PaneStatus}}},
+		},
+	}
+}
+
+// DailySeries returns one entry per calendar day for the given number of days
+// ending on the day of end, oldest first. Days without data have zero cost.
+func DailySeries(daily []DailyCost, end time.Time, days int) []DailyCost {
+	if days <= 0 {
+		return nil
+	}
+
+	costs := make(map[string]DailyCost, len(daily))
+	for _, day := range daily {
+		costs[day.Date.Format("2006-01-02")] = day
+	}
+
+	series := make([]DailyCost, days)
+	for i := range series {
+		date := end.AddDate(0, 0, i-days+1)
+		day, exists := costs[date.Format("2006-01-02")]
+		if !exists {
+			day = DailyCost{Date: date}
+		}
+		series[i] = day
+	}
+	return series
+}
@@ -6,10 +6,32 @@ import (
 
 // DisplayConfig represents the display configuration
 type DisplayConfig struct {
-	RefreshRate time.Duration `json:"refresh_rate"`
-	Size        DisplaySize   `json:"size"`
+	RefreshRate  time.Duration   `json:"refresh_rate"`
+	Size         DisplaySize     `json:"size"`
+	Format       NumberFormat    `json:"format"`
+	ColorProfile ColorProfile    `json:"color_profile"`
+	Dashboard    DashboardConfig `json:"dashboard"`
 }
 
+// ColorProfile defines the color capability of the output terminal
+type ColorProfile string
+
+const (
+	ColorProfileTrueColor ColorProfile = "truecolor"
+	ColorProfileANSI256   ColorProfile = "256"
+	ColorProfileANSI      ColorProfile = "16"
+	ColorProfileNone      ColorProfile = "none"
+)
+
+// ColorMode defines how the color profile is chosen
+type ColorMode string
+
+const (
+	ColorModeAuto   ColorMode = "auto"
+	ColorModeAlways ColorMode = "always"
+	ColorModeNever  ColorMode = "never"
+)
+
 // DisplaySize defines the display size configuration
 type DisplaySize struct {
 	Width  int `json:"width"`
@@ -19,9 +41,90 @@ type DisplaySize struct {
 // DisplayData represents the data to be displayed
 type DisplayData struct {
 	Cost        *CostData       `json:"cost"`
+	FullCost    *CostData       `json:"-"` // Cost before the report period and model are applied, Cost when nil
 	Animation   *AnimationFrame `json:"animation"`
 	Config      *DisplayConfig  `json:"config"`
 	LastUpdated time.Time       `json:"last_updated"`
+	Highlight   []bool          `json:"highlight,omitempty"` // Characters of the cost text to emphasize
+	Now         time.Time       `json:"-"`                   // Time the data is shown at, the current time when zero
+}
+
+// CurrentTime returns the time the data is shown at, which decides the current day and month
+func (d *DisplayData) CurrentTime() time.Time {
+	if d.Now.IsZero() {
+		return time.Now()
+	}
+	return d.Now
+}
+
+// UnfilteredCost returns the cost before the report period and model are applied
+func (d *DisplayData) UnfilteredCost() *CostData {
+	if d.FullCost != nil {
+		return d.FullCost
+	}
+	return d.Cost
+}
+
+// ArtCell is one character cell of rendered art
+type ArtCell struct {
+	Char  rune   `json:"char"`
+	Color string `json:"color,omitempty"` // Hex color, empty for blank cells
+}
+
+// ArtGrid is rendered art as rows of colored cells, for output other than terminals
+type ArtGrid struct {
+	Width  int         `json:"width"`
+	Height int         `json:"height"`
+	Rows   [][]ArtCell `json:"rows"`
+}
+
+// Trim removes the blank cells around the art, keeping the given margin of blank
+// columns and rows on every side
+func (g *ArtGrid) Trim(marginColumns, marginRows int) *ArtGrid {
+	top, bottom, left, right := -1, -1, g.Width, -1
+	for y, row := range g.Rows {
+		for x, cell := range row {
+			if cell.Color == "" {
+				continue
+			}
+			if top < 0 {
+				top = y
+			}
+			bottom = y
+			left = min(left, x)
+			right = max(right, x)
+		}
+	}
+	if top < 0 {
+		return &ArtGrid{}
+	}
+
+	blank := func(width int) []ArtCell {
+		row := make([]ArtCell, width)
+		for i := range row {
+			row[i] = ArtCell{Char: ' '}
+		}
+		return row
+	}
+
+	width := right - left + 1 + 2*marginColumns
+	trimmed := &ArtGrid{Width: width}
+	for i := 0; i < marginRows; i++ {
+		trimmed.Rows = append(trimmed.Rows, blank(width))
+	}
+	for _, row := range g.Rows[top : bottom+1] {
+		cells := blank(width)
+		for x := left; x <= right && x < len(row); x++ {
+			cells[marginColumns+x-left] = row[x]
+		}
+		trimmed.Rows = append(trimmed.Rows, cells)
+	}
+	for i := 0; i < marginRows; i++ {
+		trimmed.Rows = append(trimmed.Rows, blank(width))
+	}
+	trimmed.Height = len(trimmed.Rows)
+
+	return trimmed
 }
 
 // DisplayService defines the interface for display operations
@@ -4,7 +4,37 @@ import "errors"
 
 // Common errors used across the domain
 var (
-	ErrPluginNotEnabled = errors.New("plugin is not enabled")
-	ErrInvalidConfig    = errors.New("invalid configuration")
-	ErrDataNotFound     = errors.New("data not found")
+	ErrPluginNotEnabled    = errors.New("plugin is not enabled")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrDataNotFound        = errors.New("data not found")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
 )
+
+// Classes of data source failures, each with its own remedy
+var (
+	ErrBinaryNotFound   = errors.New("command not found")
+	ErrNodeMissing      = errors.New("node.js is not installed")
+	ErrTimeout          = errors.New("timed out")
+	ErrParseFailure     = errors.New("unreadable output")
+	ErrNoUsageData      = errors.New("no usage data")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrCommandFailed    = errors.New("command failed")
+)
+
+// DataSourceError is a classified data source failure. It matches its class and
+// its cause with errors.Is, and keeps what the command wrote to stderr.
+type DataSourceError struct {
+	Class  error  // One of the data source failure classes
+	Stderr string // Error output of the command, if one was run
+	Err    error  // Detailed cause, whose message is the error message
+}
+
+// Error returns the message of the cause
+func (e *DataSourceError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the class and the cause
+func (e *DataSourceError) Unwrap() []error {
+	return []error{e.Class, e.Err}
+}
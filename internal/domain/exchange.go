@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExchangeRates represents a table of conversion rates relative to a base currency
+type ExchangeRates struct {
+	Base  string             `json:"base"`
+	Date  time.Time          `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// CurrencyConversion records how cost data was converted for display
+type CurrencyConversion struct {
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Rate     float64   `json:"rate"`
+	RateDate time.Time `json:"rate_date"`
+}
+
+// Rate returns the multiplier converting amounts in one currency to another.
+// Conversions between two non-base currencies go through the base currency.
+func (r *ExchangeRates) Rate(from, to string) (float64, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, err := r.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := r.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return toRate / fromRate, nil
+}
+
+// baseRate returns how many units of the currency one unit of the base buys
+func (r *ExchangeRates) baseRate(code string) (float64, error) {
+	if strings.EqualFold(code, r.Base) {
+		return 1, nil
+	}
+
+	rate, exists := r.Rates[code]
+	if !exists || rate <= 0 {
+		return 0, fmt.Errorf("%w: no exchange rate for %s", ErrUnsupportedCurrency, code)
+	}
+
+	return rate, nil
+}
+
+// ConvertCostData returns a copy of the cost data expressed in the target currency
+func ConvertCostData(data *CostData, rates *ExchangeRates, to string) (*CostData, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	from := data.Currency
+	if from == "" {
+		from = DefaultCurrency
+	}
+
+	rate, err := rates.Rate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := *data
+	converted.TotalCost = data.TotalCost * rate
+	converted.Currency = strings.ToUpper(to)
+	converted.Conversion = &CurrencyConversion{
+		From:     strings.ToUpper(from),
+		To:       strings.ToUpper(to),
+		Rate:     rate,
+		RateDate: rates.Date,
+	}
+
+	converted.ModelBreakdown = convertBreakdown(data.ModelBreakdown, rate)
+
+	if data.Daily != nil {
+		converted.Daily = make([]DailyCost, len(data.Daily))
+		for i, day := range data.Daily {
+			day.Cost *= rate
+			day.ModelBreakdown = convertBreakdown(day.ModelBreakdown, rate)
+			converted.Daily[i] = day
+		}
+	}
+
+	if data.Contributors != nil {
+		converted.Contributors = make([]Contribution, len(data.Contributors))
+		for i, contribution := range data.Contributors {
+			contribution.Cost *= rate
+			converted.Contributors[i] = contribution
+		}
+	}
+
+	return &converted, nil
+}
+
+// convertBreakdown returns a copy of the per-model costs multiplied by rate
+func convertBreakdown(breakdown map[string]float64, rate float64) map[string]float64 {
+	if breakdown == nil {
+		return nil
+	}
+
+	converted := make(map[string]float64, len(breakdown))
+	for model, cost := range breakdown {
+		converted[model] = cost * rate
+	}
+	return converted
+}
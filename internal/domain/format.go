@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat controls how cost amounts are rendered as text
+type NumberFormat struct {
+	ThousandsSeparator string         `json:"thousands_separator" yaml:"thousands_separator"`
+	DecimalSeparator   string         `json:"decimal_separator" yaml:"decimal_separator"`
+	Decimals           int            `json:"decimals" yaml:"decimals"`                                   // Negative values use the currency default
+	Abbreviate         bool           `json:"abbreviate" yaml:"abbreviate"`                               // Abbreviate thousands and millions as K and M
+	SymbolPosition     SymbolPosition `json:"symbol_position,omitempty" yaml:"symbol_position,omitempty"` // Empty uses the currency default
+}
+
+// DefaultNumberFormat returns the default number format
+func DefaultNumberFormat() NumberFormat {
+	return NumberFormat{
+		ThousandsSeparator: ",",
+		DecimalSeparator:   ".",
+		Decimals:           -1,
+		Abbreviate:         false,
+	}
+}
+
+// abbreviation pairs a magnitude with its suffix
+type abbreviation struct {
+	threshold float64
+	suffix    string
+}
+
+var abbreviations = []abbreviation{
+	{threshold: 1e6, suffix: "M"},
+	{threshold: 1e3, suffix: "K"},
+}
+
+// FormatCost formats an amount with the symbol and decimals of the given currency
+func FormatCost(amount float64, currencyCode string, format NumberFormat) string {
+	currency := LookupCurrency(currencyCode)
+
+	decimals := format.Decimals
+	if decimals < 0 {
+		decimals = currency.Decimals
+	}
+
+	number := FormatNumber(math.Abs(amount), decimals, format)
+
+	position := format.SymbolPosition
+	if position == "" {
+		position = currency.Position
+	}
+
+	var result string
+	if position == SymbolSuffix {
+		result = number + " " + currency.Symbol
+	} else {
+		result = currency.Symbol + number
+	}
+
+	if amount < 0 && roundTo(math.Abs(amount), decimals) > 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
+// FormatNumber formats a number with separators, fixed decimals and optional abbreviation
+func FormatNumber(value float64, decimals int, format NumberFormat) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	negative := value < 0
+	value = math.Abs(value)
+
+	suffix := ""
+	if format.Abbreviate {
+		for i, abbr := range abbreviations {
+			if value < abbr.threshold {
+				continue
+			}
+			scaled := value / abbr.threshold
+			// Promote to the next larger unit when rounding would produce e.g. 1000.00K
+			if i > 0 && roundTo(scaled, decimals) >= 1000 {
+				abbr = abbreviations[i-1]
+				scaled = value / abbr.threshold
+			}
+			value = scaled
+			suffix = abbr.suffix
+			break
+		}
+	}
+
+	formatted := strconv.FormatFloat(value, 'f', decimals, 64)
+	integerPart, fractionPart, _ := strings.Cut(formatted, ".")
+
+	var result strings.Builder
+	if negative && strings.Trim(formatted, "0.") != "" {
+		result.WriteString("-")
+	}
+	result.WriteString(groupThousands(integerPart, format.ThousandsSeparator))
+	if fractionPart != "" {
+		decimalSeparator := format.DecimalSeparator
+		if decimalSeparator == "" {
+			decimalSeparator = "."
+		}
+		result.WriteString(decimalSeparator)
+		result.WriteString(fractionPart)
+	}
+	result.WriteString(suffix)
+
+	return result.String()
+}
+
+// groupThousands inserts the separator between every group of three digits
+func groupThousands(digits, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var result strings.Builder
+	leading := len(digits) % 3
+	if leading > 0 {
+		result.WriteString(digits[:leading])
+	}
+	for i := leading; i < len(digits); i += 3 {
+		if result.Len() > 0 {
+			result.WriteString(separator)
+		}
+		result.WriteString(digits[i : i+3])
+	}
+
+	return result.String()
+}
+
+// roundTo rounds a value to the given number of decimal places
+func roundTo(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
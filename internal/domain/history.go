@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+)
+
+// HistoryEventType defines the kind of history entry
+type HistoryEventType string
+
+const (
+	HistoryEventMilestone HistoryEventType = "milestone"
+)
+
+// HistoryEntry represents a notable event recorded in the history store
+type HistoryEntry struct {
+	Time     time.Time        `json:"time"`
+	Type     HistoryEventType `json:"type"`
+	Amount   float64          `json:"amount"` // Milestone amount for milestone events
+	Cost     float64          `json:"cost"`   // Total cost when the event happened
+	Currency string           `json:"currency"`
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// KioskConfig represents the unattended display mode for wall-mounted screens
+type KioskConfig struct {
+	Enabled        bool          `json:"enabled" yaml:"enabled"`
+	DriftInterval  time.Duration `json:"drift_interval" yaml:"drift_interval"`   // Time between one-cell moves of the art, zero disables drifting
+	DriftRange     int           `json:"drift_range" yaml:"drift_range"`         // Farthest the art moves sideways, in cells
+	RotateInterval time.Duration `json:"rotate_interval" yaml:"rotate_interval"` // Time between report period and display changes, zero disables rotation
+	RetryInterval  time.Duration `json:"retry_interval" yaml:"retry_interval"`   // Wait before fetching again after a data error
+	QuietHours     QuietHours    `json:"quiet_hours" yaml:"quiet_hours"`
+}
+
+// QuietHours represents a daily period in which the palette is dimmed
+type QuietHours struct {
+	Start      string  `json:"start" yaml:"start"`           // Local time as HH:MM, empty disables quiet hours
+	End        string  `json:"end" yaml:"end"`               // Local time as HH:MM, may be before Start to span midnight
+	Brightness float64 `json:"brightness" yaml:"brightness"` // Palette brightness from 0 to 1
+}
+
+// DefaultKioskConfig returns the default kiosk settings
+func DefaultKioskConfig() KioskConfig {
+	return KioskConfig{
+		Enabled:        false,
+		DriftInterval:  time.Minute,
+		DriftRange:     4,
+		RotateInterval: 30 * time.Second,
+		RetryInterval:  30 * time.Second,
+		QuietHours: QuietHours{
+			Brightness: 0.3,
+		},
+	}
+}
+
+// ParseClockTime parses a time of day such as "22:30" into the offset from midnight
+func ParseClockTime(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day '%s', expected HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// IsSet reports whether quiet hours are configured
+func (q QuietHours) IsSet() bool {
+	return q.Start != "" && q.End != ""
+}
+
+// Contains reports whether the given time falls within the quiet hours.
+// The start is inclusive and the end exclusive.
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.IsSet() {
+		return false
+	}
+
+	start, err := ParseClockTime(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClockTime(q.End)
+	if err != nil {
+		return false
+	}
+
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if start <= end {
+		return offset >= start && offset < end
+	}
+	return offset >= start || offset < end
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MilestoneConfig represents the spending thresholds that trigger a celebration.
+// Amounts are in the display currency.
+type MilestoneConfig struct {
+	Enabled  bool          `json:"enabled" yaml:"enabled"`
+	Every    float64       `json:"every" yaml:"every"`     // Celebrate every multiple of this amount, zero disables
+	Amounts  []float64     `json:"amounts" yaml:"amounts"` // Additional fixed thresholds
+	Bell     bool          `json:"bell" yaml:"bell"`       // Ring the terminal bell
+	Duration time.Duration `json:"duration" yaml:"duration"`
+}
+
+// DefaultMilestoneConfig returns the default milestone settings
+func DefaultMilestoneConfig() MilestoneConfig {
+	return MilestoneConfig{
+		Enabled:  true,
+		Every:    100,
+		Duration: 4 * time.Second,
+	}
+}
+
+// CrossedMilestone returns the highest milestone in (previous, current],
+// or false when the cost did not rise past any milestone
+func CrossedMilestone(config MilestoneConfig, previous, current float64) (float64, bool) {
+	if !config.Enabled || current <= previous {
+		return 0, false
+	}
+
+	crossed := 0.0
+	found := false
+
+	if config.Every > 0 {
+		highest := math.Floor(current/config.Every) * config.Every
+		if highest > previous && highest > 0 {
+			crossed = highest
+			found = true
+		}
+	}
+
+	for _, amount := range config.Amounts {
+		if amount > previous && amount <= current && (!found || amount > crossed) {
+			crossed = amount
+			found = true
+		}
+	}
+
+	return crossed, found
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"time"
+)
+
+// PluginKind identifies what a plugin provides
+type PluginKind string
+
+const (
+	PluginKindDataSource PluginKind = "datasource"
+	PluginKindDisplay    PluginKind = "display"
+	PluginKindAnimation  PluginKind = "animation"
+)
+
+// IsValid reports whether the kind is known
+func (k PluginKind) IsValid() bool {
+	switch k {
+	case PluginKindDataSource, PluginKindDisplay, PluginKindAnimation:
+		return true
+	}
+	return false
+}
+
+// PluginState is a stage in the lifecycle of a registered plugin
+type PluginState string
+
+const (
+	PluginStateRegistered   PluginState = "registered"
+	PluginStateInitializing PluginState = "initializing"
+	PluginStateReady        PluginState = "ready"
+	PluginStateDegraded     PluginState = "degraded" // Failing, but not often enough to be replaced
+	PluginStateFailed       PluginState = "failed"   // Replaced by another plugin of the same kind when possible
+	PluginStateStopped      PluginState = "stopped"
+)
+
+// IsUsable reports whether a plugin in this state can serve requests
+func (s PluginState) IsUsable() bool {
+	return s == PluginStateReady || s == PluginStateDegraded
+}
+
+// PluginStatus is the lifecycle state of a registered plugin
+type PluginStatus struct {
+	Name      string      `json:"name"`
+	Kind      PluginKind  `json:"kind"`
+	State     PluginState `json:"state"`
+	Failures  int         `json:"failures"`             // Consecutive failed calls and health checks
+	LastError string      `json:"last_error,omitempty"` // Most recent failure
+	Since     time.Time   `json:"since"`                // When the plugin entered its state
+}
+
+// DefaultExternalPluginTimeout limits each call to an external plugin unless configured otherwise
+const DefaultExternalPluginTimeout = 10 * time.Second
+
+// ExternalPluginConfig declares a plugin that runs as a separate process
+type ExternalPluginConfig struct {
+	Name    string                 `json:"name" yaml:"name"` // Overrides the name reported by the plugin
+	Kind    PluginKind             `json:"kind" yaml:"kind"` // Expected kind, checked against the plugin when set
+	Command string                 `json:"command" yaml:"command"`
+	Args    []string               `json:"args" yaml:"args"`
+	Timeout time.Duration          `json:"timeout" yaml:"timeout"` // Limit for each call, zero uses the default
+	Config  map[string]interface{} `json:"config" yaml:"config"`   // Passed to the plugin when it is initialized
+
+	ConfigSchema []ConfigOption  `json:"config_schema,omitempty" yaml:"config_schema"` // Settings the plugin accepts, overrides the handshake
+	Manifest     *PluginManifest `json:"-" yaml:"-"`                                   // Set for plugins discovered through a manifest
+}
+
+// DefaultCompositeTimeout limits each source of the composite data source unless configured otherwise
+const DefaultCompositeTimeout = 30 * time.Second
+
+// CompositeConfig lists the data sources whose cost is added up by the composite data source
+type CompositeConfig struct {
+	Timeout time.Duration     `json:"timeout" yaml:"timeout"` // Limit for each source, zero uses the default
+	Sources []CompositeSource `json:"sources" yaml:"sources"`
+}
+
+// CompositeSource is one contributor to the composite data source
+type CompositeSource struct {
+	Label      string                 `json:"label" yaml:"label"`           // Shown in breakdowns, defaults to the data source name
+	DataSource string                 `json:"datasource" yaml:"datasource"` // Name of the data source plugin
+	Config     map[string]interface{} `json:"config" yaml:"config"`         // Runs a separate instance of a built-in data source with these settings
+}
+
+// Name returns the label of the source, or the data source name without one
+func (s CompositeSource) Name() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.DataSource
+}
+
+// PluginManifestFile is the name of the manifest describing a plugin in its own directory
+const PluginManifestFile = "plugin.yaml"
+
+// PluginManifest describes an external plugin without starting it
+type PluginManifest struct {
+	Name         string         `json:"name" yaml:"name"`
+	Kind         PluginKind     `json:"kind" yaml:"kind"`
+	Version      string         `json:"version" yaml:"version"`
+	Description  string         `json:"description" yaml:"description"`
+	Command      string         `json:"command" yaml:"command"` // Relative paths are resolved against the manifest directory
+	Args         []string       `json:"args" yaml:"args"`
+	Timeout      time.Duration  `json:"timeout" yaml:"timeout"`
+	ConfigSchema []ConfigOption `json:"config_schema" yaml:"config_schema"`
+}
+
+// ConfigOption describes a setting accepted by a plugin
+type ConfigOption struct {
+	Name        string      `json:"name" yaml:"name"`
+	Type        string      `json:"type" yaml:"type"` // string, number, boolean, duration, list or map
+	Description string      `json:"description" yaml:"description"`
+	Default     interface{} `json:"default,omitempty" yaml:"default"`
+	Required    bool        `json:"required,omitempty" yaml:"required"`
+}
+
+// PluginInfo describes a plugin for listings
+type PluginInfo struct {
+	Name         string         `json:"name"`
+	Kind         PluginKind     `json:"kind"`
+	Version      string         `json:"version"`
+	Description  string         `json:"description"`
+	Source       string         `json:"source"`  // "built-in" or the command of an external plugin
+	Enabled      bool           `json:"enabled"` // Not disabled in the config file
+	ConfigSchema []ConfigOption `json:"config_schema,omitempty"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+)
+
+// ReportPeriod defines the time span the cost is reported for
+type ReportPeriod string
+
+const (
+	PeriodToday ReportPeriod = "today"
+	PeriodMonth ReportPeriod = "month"
+	PeriodAll   ReportPeriod = "all"
+)
+
+// ReportPeriods returns the report periods in display order
+func ReportPeriods() []ReportPeriod {
+	return []ReportPeriod{PeriodAll, PeriodMonth, PeriodToday}
+}
+
+// Contains reports whether the date falls within the period ending at now
+func (p ReportPeriod) Contains(date, now time.Time) bool {
+	switch p {
+	case PeriodToday:
+		return date.Format("2006-01-02") == now.Format("2006-01-02")
+	case PeriodMonth:
+		return date.Format("2006-01") == now.Format("2006-01")
+	default:
+		return true
+	}
+}
+
+// FilterCostData returns the cost data restricted to a report period and,
+// when model is not empty, to a single model. Period totals are summed from
+// the daily series; the "all" period keeps the reported totals.
+func FilterCostData(data *CostData, period ReportPeriod, model string, now time.Time) *CostData {
+	if data == nil {
+		return nil
+	}
+
+	allTime := period == PeriodAll || period == ""
+	if allTime && model == "" {
+		return data
+	}
+
+	filtered := *data
+	filtered.Daily = nil
+	filtered.Contributors = nil // Only known for the reported totals
+	if !allTime {
+		filtered.TotalCost = 0
+		filtered.Tokens = TokenUsage{}
+		filtered.ModelBreakdown = make(map[string]float64)
+	}
+
+	for _, day := range data.Daily {
+		if !period.Contains(day.Date, now) {
+			continue
+		}
+
+		if !allTime {
+			filtered.TotalCost += day.Cost
+			filtered.Tokens.Input += day.Tokens.Input
+			filtered.Tokens.Output += day.Tokens.Output
+			for name, cost := range day.ModelBreakdown {
+				filtered.ModelBreakdown[name] += cost
+			}
+		}
+
+		if model != "" {
+			day = DailyCost{
+				Date:           day.Date,
+				Cost:           day.ModelBreakdown[model],
+				ModelBreakdown: map[string]float64{model: day.ModelBreakdown[model]},
+			}
+		}
+		filtered.Daily = append(filtered.Daily, day)
+	}
+
+	if model != "" {
+		filtered.TotalCost = filtered.ModelBreakdown[model]
+		filtered.ModelBreakdown = map[string]float64{model: filtered.TotalCost}
+		filtered.Tokens = TokenUsage{} // Tokens are not reported per model
+	}
+
+	return &filtered
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+)
+
+// TeamSnapshot is the cost data one instance shares with its team
+type TeamSnapshot struct {
+	Member    string    `json:"member"` // Whose cost it is, also the file name of the snapshot
+	Host      string    `json:"host"`   // Machine that wrote the snapshot
+	WrittenAt time.Time `json:"written_at"`
+	Cost      *CostData `json:"cost"`
+}
+
+// IsStale reports whether the snapshot was written longer than maxAge before now
+func (s TeamSnapshot) IsStale(now time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && now.Sub(s.WrittenAt) > maxAge
+}
+
+// TeamConfig represents sharing cost data with a team through a shared directory
+type TeamConfig struct {
+	Dir        string        `json:"dir" yaml:"dir"`               // Shared directory holding one snapshot per member, empty disables team sync
+	Share      bool          `json:"share" yaml:"share"`           // Write the cost of this instance to the directory
+	Member     string        `json:"member" yaml:"member"`         // Name in the team breakdown, defaults to the hostname
+	DataSource string        `json:"datasource" yaml:"datasource"` // Local data source whose cost is shared
+	Interval   time.Duration `json:"interval" yaml:"interval"`     // Time between snapshots
+	MaxAge     time.Duration `json:"max_age" yaml:"max_age"`       // Older snapshots are ignored, zero keeps them all
+}
+
+// DefaultTeamConfig returns the default team sync settings
+func DefaultTeamConfig() TeamConfig {
+	return TeamConfig{
+		DataSource: "ccusage-cli",
+		Interval:   5 * time.Minute,
+		MaxAge:     24 * time.Hour,
+	}
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Theme represents a named color palette for animations
+type Theme struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Colors      []string `json:"colors" yaml:"colors"`
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+	"unicode"
+)
+
+// Easing defines how a transition progresses over time
+type Easing string
+
+const (
+	EasingLinear    Easing = "linear"
+	EasingEaseIn    Easing = "ease-in"
+	EasingEaseOut   Easing = "ease-out"
+	EasingEaseInOut Easing = "ease-in-out"
+)
+
+// TransitionConfig represents the count-up transition shown when the cost changes
+type TransitionConfig struct {
+	Duration      time.Duration `json:"duration" yaml:"duration"` // Zero disables the transition
+	Easing        Easing        `json:"easing" yaml:"easing"`
+	FlashDigits   bool          `json:"flash_digits" yaml:"flash_digits"`
+	DeltaBadge    bool          `json:"delta_badge" yaml:"delta_badge"`
+	BadgeDuration time.Duration `json:"badge_duration" yaml:"badge_duration"`
+}
+
+// DefaultTransitionConfig returns the default count-up transition
+func DefaultTransitionConfig() TransitionConfig {
+	return TransitionConfig{
+		Duration:      800 * time.Millisecond,
+		Easing:        EasingEaseOut,
+		FlashDigits:   true,
+		DeltaBadge:    true,
+		BadgeDuration: 3 * time.Second,
+	}
+}
+
+// IsValidEasing reports whether the easing is supported
+func IsValidEasing(easing Easing) bool {
+	switch easing {
+	case EasingLinear, EasingEaseIn, EasingEaseOut, EasingEaseInOut:
+		return true
+	}
+	return false
+}
+
+// Ease maps linear progress in [0, 1] to eased progress using cubic curves
+func Ease(easing Easing, progress float64) float64 {
+	progress = max(0, min(1, progress))
+
+	switch easing {
+	case EasingEaseIn:
+		return progress * progress * progress
+	case EasingEaseOut:
+		inverse := 1 - progress
+		return 1 - inverse*inverse*inverse
+	case EasingEaseInOut:
+		if progress < 0.5 {
+			return 4 * progress * progress * progress
+		}
+		inverse := -2*progress + 2
+		return 1 - inverse*inverse*inverse/2
+	default:
+		return progress
+	}
+}
+
+// Interpolate returns the value between from and to at the given eased progress
+func Interpolate(from, to, progress float64) float64 {
+	return from + (to-from)*progress
+}
+
+// ChangedChars marks the characters of next that differ from previous.
+// The texts are compared right-aligned so that digits of equal place value line up,
+// except for a shared non-digit prefix such as the currency symbol.
+func ChangedChars(previous, next string) []bool {
+	prevRunes := []rune(previous)
+	nextRunes := []rune(next)
+	changed := make([]bool, len(nextRunes))
+
+	prefix := 0
+	for prefix < len(prevRunes) && prefix < len(nextRunes) &&
+		prevRunes[prefix] == nextRunes[prefix] && !unicode.IsDigit(nextRunes[prefix]) {
+		prefix++
+	}
+
+	offset := len(prevRunes) - len(nextRunes)
+	for i := prefix; i < len(nextRunes); i++ {
+		j := i + offset
+		changed[i] = j < prefix || prevRunes[j] != nextRunes[i]
+	}
+
+	return changed
+}
@@ -0,0 +1,64 @@
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Header is the first line of an asciicast v2 recording
+// (https://docs.asciinema.org/manual/asciicast/v2/)
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer writes terminal output as asciicast v2 events
+type Writer struct {
+	out io.Writer
+}
+
+// NewWriter writes the header and returns a writer for the output events
+func NewWriter(out io.Writer, header Header) (*Writer, error) {
+	header.Version = 2
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode asciicast header: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(out, "%s\n", line); err != nil {
+		return nil, fmt.Errorf("failed to write asciicast header: %w", err)
+	}
+
+	return &Writer{out: out}, nil
+}
+
+// WriteOutput writes an output event at the given offset from the start of the recording
+func (w *Writer) WriteOutput(offset time.Duration, data string) error {
+	line, err := json.Marshal([]interface{}{offset.Seconds(), "o", data})
+	if err != nil {
+		return fmt.Errorf("failed to encode asciicast event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w.out, "%s\n", line); err != nil {
+		return fmt.Errorf("failed to write asciicast event: %w", err)
+	}
+
+	return nil
+}
+
+// ResetScreen hides the cursor and clears the screen before the first frame
+const ResetScreen = "\x1b[?25l\x1b[2J"
+
+// Frame returns the output that redraws the whole screen with the given view.
+// Lines are cleared to their end so nothing of the previous frame remains.
+func Frame(view string) string {
+	lines := strings.Split(view, "\n")
+	return "\x1b[H" + strings.Join(lines, "\x1b[K\r\n") + "\x1b[K\x1b[J"
+}
@@ -0,0 +1,90 @@
+package costcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// FileStore keeps the latest cost data in a JSON file
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a cost cache backed by the given file
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Path returns the path of the cache file
+func (f *FileStore) Path() string {
+	return f.path
+}
+
+// Save replaces the cached cost data. The file is written under a temporary
+// name first so that a crash never leaves it half written.
+func (f *FileStore) Save(ctx context.Context, cached domain.CachedCost) error {
+	content, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode cost cache: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cost cache directory: %w", err)
+	}
+
+	temp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cost cache in '%s': %w", dir, err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to write cost cache '%s': %w", temp.Name(), err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write cost cache '%s': %w", temp.Name(), err)
+	}
+
+	if err := os.Rename(temp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to replace cost cache '%s': %w", f.path, err)
+	}
+
+	return nil
+}
+
+// Load returns the cached cost data. A missing cache file yields nil.
+func (f *FileStore) Load(ctx context.Context) (*domain.CachedCost, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cost cache '%s': %w", f.path, err)
+	}
+
+	var cached domain.CachedCost
+	if err := json.Unmarshal(content, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse cost cache '%s': %w", f.path, err)
+	}
+	if cached.Cost == nil {
+		return nil, nil
+	}
+
+	return &cached, nil
+}
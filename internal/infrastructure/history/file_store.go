@@ -0,0 +1,92 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// FileStore stores history entries in a JSON Lines file, one entry per line
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a history store backed by the given file
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Path returns the path of the history file
+func (f *FileStore) Path() string {
+	return f.path
+}
+
+// Record appends an entry to the history file, creating it if needed
+func (f *FileStore) Record(ctx context.Context, entry domain.HistoryEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file '%s': %w", f.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file '%s': %w", f.path, err)
+	}
+
+	return nil
+}
+
+// List returns all entries in the order they were recorded.
+// A missing history file yields no entries.
+func (f *FileStore) List(ctx context.Context) ([]domain.HistoryEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history file '%s': %w", f.path, err)
+	}
+	defer file.Close()
+
+	var entries []domain.HistoryEntry
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry domain.HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse history file '%s' line %d: %w", f.path, lineNumber, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file '%s': %w", f.path, err)
+	}
+
+	return entries, nil
+}
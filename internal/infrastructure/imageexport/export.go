@@ -0,0 +1,207 @@
+package imageexport
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+)
+
+// Size of a character cell in pixels at scale 1. Cells are about twice as tall
+// as wide, like terminal cells, with the glyph centered vertically.
+const (
+	CellWidth  = glyphWidth + 1
+	CellHeight = 12
+)
+
+// Options controls how art grids are turned into images
+type Options struct {
+	Scale      int           // Pixels per font pixel
+	Background string        // Hex background color
+	Delay      time.Duration // Time each animation frame is shown
+}
+
+// DefaultOptions returns the default export options
+func DefaultOptions() Options {
+	return Options{
+		Scale:      2,
+		Background: "#000000",
+		Delay:      100 * time.Millisecond,
+	}
+}
+
+// WriteSVG writes the grid as an SVG image with one text element per run of equally colored cells
+func WriteSVG(w io.Writer, grid *domain.ArtGrid, options Options) error {
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	cellWidth, cellHeight := CellWidth*options.Scale, CellHeight*options.Scale
+	width, height := grid.Width*cellWidth, grid.Height*cellHeight
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", html.EscapeString(options.Background))
+	fmt.Fprintf(&svg, `<g font-family="monospace" font-size="%d" xml:space="preserve">`+"\n", cellHeight)
+
+	for y, row := range grid.Rows {
+		baseline := y*cellHeight + cellHeight*4/5
+		for start := 0; start < len(row); {
+			end := start + 1
+			for end < len(row) && row[end].Color == row[start].Color {
+				end++
+			}
+
+			if row[start].Color != "" {
+				var text strings.Builder
+				for _, cell := range row[start:end] {
+					text.WriteRune(cell.Char)
+				}
+				fmt.Fprintf(&svg, `<text x="%d" y="%d" fill="%s" textLength="%d" lengthAdjust="spacingAndGlyphs">%s</text>`+"\n",
+					start*cellWidth, baseline, html.EscapeString(row[start].Color), (end-start)*cellWidth, html.EscapeString(text.String()))
+			}
+			start = end
+		}
+	}
+
+	svg.WriteString("</g>\n</svg>\n")
+
+	if _, err := io.WriteString(w, svg.String()); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
+
+// WritePNG writes the grid as a PNG image drawn with the bundled bitmap font
+func WritePNG(w io.Writer, grid *domain.ArtGrid, options Options) error {
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	img := rasterize(grid, gridPalette([]*domain.ArtGrid{grid}, options.Background), options)
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to write PNG: %w", err)
+	}
+	return nil
+}
+
+// WriteGIF writes the grids as the frames of an endlessly looping animated GIF
+func WriteGIF(w io.Writer, grids []*domain.ArtGrid, options Options) error {
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	if len(grids) == 0 {
+		return fmt.Errorf("no frames to write")
+	}
+
+	colors := gridPalette(grids, options.Background)
+	delay := max(1, int(options.Delay/(10*time.Millisecond))) // GIF delays are in 1/100 s
+	animation := &gif.GIF{}
+	for _, grid := range grids {
+		animation.Image = append(animation.Image, rasterize(grid, colors, options))
+		animation.Delay = append(animation.Delay, delay)
+	}
+
+	if err := gif.EncodeAll(w, animation); err != nil {
+		return fmt.Errorf("failed to write GIF: %w", err)
+	}
+	return nil
+}
+
+// validateOptions checks the export options
+func validateOptions(options Options) error {
+	if options.Scale < 1 {
+		return fmt.Errorf("scale must be at least 1")
+	}
+
+	if _, err := terminal.ParseHexColor(options.Background); err != nil {
+		return fmt.Errorf("invalid background color: %w", err)
+	}
+
+	return nil
+}
+
+// gridPalette collects the colors used by the grids, background first.
+// With more than 256 colors the web-safe palette approximates them.
+func gridPalette(grids []*domain.ArtGrid, background string) color.Palette {
+	seen := map[string]bool{background: true}
+	backgroundColor, _ := terminal.ParseHexColor(background)
+	colors := color.Palette{backgroundColor}
+
+	for _, grid := range grids {
+		for _, row := range grid.Rows {
+			for _, cell := range row {
+				if cell.Color == "" || seen[cell.Color] {
+					continue
+				}
+				seen[cell.Color] = true
+				if parsed, err := terminal.ParseHexColor(cell.Color); err == nil {
+					colors = append(colors, parsed)
+				}
+			}
+		}
+	}
+
+	if len(colors) > 256 {
+		return append(color.Palette{backgroundColor}, palette.WebSafe...)
+	}
+	return colors
+}
+
+// rasterize draws the grid into a paletted image. Block elements fill their
+// cell and other characters are drawn with the bundled font.
+func rasterize(grid *domain.ArtGrid, colors color.Palette, options Options) *image.Paletted {
+	scale := options.Scale
+	cellWidth, cellHeight := CellWidth*scale, CellHeight*scale
+	img := image.NewPaletted(image.Rect(0, 0, grid.Width*cellWidth, grid.Height*cellHeight), colors)
+
+	fill := func(x, y, width, height int, index uint8) {
+		for py := y; py < y+height; py++ {
+			offset := img.PixOffset(x, py)
+			for px := 0; px < width; px++ {
+				img.Pix[offset+px] = index
+			}
+		}
+	}
+
+	glyphTop := (CellHeight - glyphHeight) / 2
+	for y, row := range grid.Rows {
+		for x, cell := range row {
+			if cell.Color == "" || cell.Char == ' ' {
+				continue
+			}
+			cellColor, err := terminal.ParseHexColor(cell.Color)
+			if err != nil {
+				continue
+			}
+			index := uint8(colors.Index(cellColor))
+			left, top := x*cellWidth, y*cellHeight
+
+			if isBlockElement(cell.Char) {
+				fill(left, top, cellWidth, cellHeight, index)
+				continue
+			}
+
+			bitmap := glyph(cell.Char)
+			for column, bits := range bitmap {
+				for fontRow := 0; fontRow < glyphHeight; fontRow++ {
+					if bits>>fontRow&1 == 1 {
+						fill(left+column*scale, top+(glyphTop+fontRow)*scale, scale, scale, index)
+					}
+				}
+			}
+		}
+	}
+
+	return img
+}
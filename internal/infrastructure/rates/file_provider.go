@@ -0,0 +1,77 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// FileProvider loads exchange rates from a local JSON rate table
+type FileProvider struct {
+	path string
+}
+
+// rateTableFile represents the on-disk rate table format
+type rateTableFile struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// NewFileProvider creates a rate provider reading the given file
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+// Name returns the provider name
+func (f *FileProvider) Name() string {
+	return "file"
+}
+
+// GetRates reads and parses the rate table file
+func (f *FileProvider) GetRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate table '%s': %w", f.path, err)
+	}
+
+	var table rateTableFile
+	if err := json.Unmarshal(content, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse rate table '%s': %w", f.path, err)
+	}
+
+	if table.Base == "" {
+		table.Base = domain.DefaultCurrency
+	}
+
+	return buildExchangeRates(table.Base, table.Date, table.Rates)
+}
+
+// buildExchangeRates normalizes currency codes and parses the rate date
+func buildExchangeRates(base, date string, rates map[string]float64) (*domain.ExchangeRates, error) {
+	exchangeRates := &domain.ExchangeRates{
+		Base:  strings.ToUpper(base),
+		Rates: make(map[string]float64, len(rates)),
+	}
+
+	if date != "" {
+		parsed, err := parseRateDate(date)
+		if err != nil {
+			return nil, err
+		}
+		exchangeRates.Date = parsed
+	}
+
+	for code, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("exchange rate for %s must be positive", code)
+		}
+		exchangeRates.Rates[strings.ToUpper(code)] = rate
+	}
+
+	return exchangeRates, nil
+}
@@ -0,0 +1,52 @@
+package rates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// StaticProvider serves exchange rates defined inline in the configuration
+type StaticProvider struct {
+	rates *domain.ExchangeRates
+}
+
+// NewStaticProvider creates a rate provider from inline rates
+func NewStaticProvider(base, date string, rates map[string]float64) (*StaticProvider, error) {
+	if base == "" {
+		base = domain.DefaultCurrency
+	}
+
+	exchangeRates, err := buildExchangeRates(base, date, rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StaticProvider{rates: exchangeRates}, nil
+}
+
+// Name returns the provider name
+func (s *StaticProvider) Name() string {
+	return "static"
+}
+
+// GetRates returns the configured rates
+func (s *StaticProvider) GetRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	return s.rates, nil
+}
+
+// parseRateDate parses a rate table date in YYYY-MM-DD or RFC 3339 format
+func parseRateDate(date string) (time.Time, error) {
+	if parsed, err := time.Parse("2006-01-02", date); err == nil {
+		return parsed, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid rate date '%s': expected YYYY-MM-DD", date)
+	}
+
+	return parsed, nil
+}
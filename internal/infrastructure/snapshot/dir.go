@@ -0,0 +1,113 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// snapshotExt is the extension of snapshot files, named after their member
+const snapshotExt = ".json"
+
+// Dir reads and writes team snapshots in a shared directory, one file per member
+type Dir struct {
+	path string
+}
+
+// NewDir creates a snapshot directory at the given path
+func NewDir(path string) *Dir {
+	return &Dir{path: path}
+}
+
+// Path returns the path of the shared directory
+func (d *Dir) Path() string {
+	return d.path
+}
+
+// Write replaces the snapshot of its member. The file is written under a temporary
+// name first so that peers and sync tools never see it half written.
+func (d *Dir) Write(snapshot domain.TeamSnapshot) error {
+	if snapshot.Member == "" || strings.ContainsAny(snapshot.Member, `/\`) || strings.HasPrefix(snapshot.Member, ".") {
+		return fmt.Errorf("invalid team member name: %q", snapshot.Member)
+	}
+
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(d.path, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	temp, err := os.CreateTemp(d.path, "."+snapshot.Member+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot in '%s': %w", d.path, err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to write snapshot '%s': %w", temp.Name(), err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot '%s': %w", temp.Name(), err)
+	}
+
+	path := filepath.Join(d.path, snapshot.Member+snapshotExt)
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// Read returns the snapshots in the directory sorted by member. Files that cannot be
+// read, or whose member does not match the file name, such as conflicted copies made
+// by sync tools, are reported in the error while the other snapshots are still returned.
+func (d *Dir) Read() ([]domain.TeamSnapshot, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory '%s': %w", d.path, err)
+	}
+
+	var snapshots []domain.TeamSnapshot
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != snapshotExt {
+			continue
+		}
+
+		path := filepath.Join(d.path, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read snapshot '%s': %w", path, err))
+			continue
+		}
+
+		var snapshot domain.TeamSnapshot
+		if err := json.Unmarshal(content, &snapshot); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse snapshot '%s': %w", path, err))
+			continue
+		}
+		if member := strings.TrimSuffix(name, snapshotExt); snapshot.Member != member {
+			errs = append(errs, fmt.Errorf("snapshot '%s' belongs to %q, not %q", path, snapshot.Member, member))
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Member < snapshots[j].Member
+	})
+
+	return snapshots, errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// Publisher periodically writes the cost of a local data source to the shared directory
+type Publisher struct {
+	dir    *Dir
+	source interfaces.DataSourcePlugin
+	member string
+	host   string
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewPublisher creates a publisher sharing the cost of source as member, written from host
+func NewPublisher(dir *Dir, source interfaces.DataSourcePlugin, member, host string) *Publisher {
+	return &Publisher{dir: dir, source: source, member: member, host: host}
+}
+
+// Publish fetches the current cost and writes it as the snapshot of the member.
+// Stale data is not written, so the previous snapshot keeps the time it was fetched at.
+func (p *Publisher) Publish(ctx context.Context) error {
+	cost, err := p.source.FetchCostData(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch cost data from %s: %w", p.source.Name(), err)
+	}
+	if cost.Stale {
+		return nil
+	}
+
+	return p.dir.Write(domain.TeamSnapshot{
+		Member:    p.member,
+		Host:      p.host,
+		WrittenAt: time.Now(),
+		Cost:      cost,
+	})
+}
+
+// Err returns the error of the latest publish, or nil when it succeeded
+func (p *Publisher) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr
+}
+
+// Run publishes right away and then at the given interval until the context is done,
+// returning once no snapshot is being written. Failures are kept for Err and retried at the next interval.
+func (p *Publisher) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Publish(ctx); ctx.Err() == nil {
+			p.mu.Lock()
+			p.lastErr = err
+			p.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
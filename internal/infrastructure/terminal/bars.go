@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// barBlocks are the partial blocks used to draw vertical bars, from empty to full
+var barBlocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// BarRows draws one vertical bar per day scaled to the highest cost, top row first,
+// with gap columns between the bars
+func BarRows(days []domain.DailyCost, height, gap int) []string {
+	if height <= 0 {
+		return nil
+	}
+
+	highest := 0.0
+	for _, day := range days {
+		highest = max(highest, day.Cost)
+	}
+
+	levels := len(barBlocks) - 1
+	rows := make([]strings.Builder, height)
+	for i, day := range days {
+		units := 0
+		if highest > 0 {
+			units = int(day.Cost/highest*float64(height*levels) + 0.5)
+		}
+		if day.Cost > 0 && units == 0 {
+			units = 1 // Keep small but non-zero days visible
+		}
+		for row := 0; row < height; row++ {
+			if i > 0 {
+				rows[row].WriteString(strings.Repeat(" ", gap))
+			}
+			fromBottom := height - 1 - row
+			rows[row].WriteRune(barBlocks[max(0, min(levels, units-fromBottom*levels))])
+		}
+	}
+
+	lines := make([]string, height)
+	for i := range rows {
+		lines[i] = rows[i].String()
+	}
+	return lines
+}
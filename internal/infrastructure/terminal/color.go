@@ -0,0 +1,173 @@
+package terminal
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// DetectColorProfile determines the color profile to render with.
+// It honors the color mode, NO_COLOR, CLICOLOR and CLICOLOR_FORCE before
+// inspecting TERM, COLORTERM and TERM_PROGRAM.
+func DetectColorProfile(mode domain.ColorMode, getenv func(string) string, isTerminal bool) domain.ColorProfile {
+	switch mode {
+	case domain.ColorModeNever:
+		return domain.ColorProfileNone
+	case domain.ColorModeAlways:
+		return atLeastANSI(envColorProfile(getenv))
+	}
+
+	// NO_COLOR takes precedence over everything in auto mode (https://no-color.org)
+	if getenv("NO_COLOR") != "" {
+		return domain.ColorProfileNone
+	}
+
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return atLeastANSI(envColorProfile(getenv))
+	}
+
+	if !isTerminal || getenv("CLICOLOR") == "0" {
+		return domain.ColorProfileNone
+	}
+
+	return envColorProfile(getenv)
+}
+
+// envColorProfile infers the color profile from terminal environment variables
+func envColorProfile(getenv func(string) string) domain.ColorProfile {
+	term := strings.ToLower(getenv("TERM"))
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+
+	if term == "dumb" {
+		return domain.ColorProfileNone
+	}
+
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return domain.ColorProfileTrueColor
+	}
+
+	for _, marker := range []string{"truecolor", "24bit", "direct", "kitty", "alacritty", "wezterm", "ghostty"} {
+		if strings.Contains(term, marker) {
+			return domain.ColorProfileTrueColor
+		}
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "vscode", "WezTerm", "ghostty":
+		return domain.ColorProfileTrueColor
+	case "Apple_Terminal":
+		return domain.ColorProfileANSI256
+	}
+
+	// Windows Terminal sets WT_SESSION but usually no TERM
+	if getenv("WT_SESSION") != "" {
+		return domain.ColorProfileTrueColor
+	}
+
+	if strings.Contains(term, "256color") {
+		return domain.ColorProfileANSI256
+	}
+
+	if term == "" {
+		return domain.ColorProfileNone
+	}
+
+	return domain.ColorProfileANSI
+}
+
+// atLeastANSI upgrades a colorless profile to basic ANSI colors
+func atLeastANSI(profile domain.ColorProfile) domain.ColorProfile {
+	if profile == domain.ColorProfileNone {
+		return domain.ColorProfileANSI
+	}
+	return profile
+}
+
+// termenvProfile converts a domain color profile to a termenv profile
+func termenvProfile(profile domain.ColorProfile) termenv.Profile {
+	switch profile {
+	case domain.ColorProfileANSI256:
+		return termenv.ANSI256
+	case domain.ColorProfileANSI:
+		return termenv.ANSI
+	case domain.ColorProfileNone:
+		return termenv.Ascii
+	default:
+		return termenv.TrueColor
+	}
+}
+
+// AdaptColor maps a hex palette color to the nearest color available in the profile
+func AdaptColor(hex string, profile domain.ColorProfile) lipgloss.TerminalColor {
+	switch profile {
+	case domain.ColorProfileNone:
+		return lipgloss.NoColor{}
+	case domain.ColorProfileANSI256, domain.ColorProfileANSI:
+		switch color := termenvProfile(profile).Color(hex).(type) {
+		case termenv.ANSIColor:
+			return lipgloss.ANSIColor(color)
+		case termenv.ANSI256Color:
+			return lipgloss.ANSIColor(color)
+		}
+		return lipgloss.NoColor{}
+	default:
+		return lipgloss.Color(hex)
+	}
+}
+
+// NewRenderer creates a lipgloss renderer that emits sequences for the given profile
+// regardless of where the output is eventually written.
+func NewRenderer(profile domain.ColorProfile) *lipgloss.Renderer {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenvProfile(profile))
+	return renderer
+}
+
+// ResetSequence is the SGR escape sequence that clears all text attributes
+const ResetSequence = termenv.CSI + termenv.ResetSeq + "m"
+
+// ForegroundSequence returns the SGR escape sequence selecting the nearest
+// available foreground color, or an empty string when the profile has no colors
+func ForegroundSequence(hex string, profile domain.ColorProfile) string {
+	color := termenvProfile(profile).Color(hex)
+	if color == nil {
+		return ""
+	}
+
+	sequence := color.Sequence(false)
+	if sequence == "" {
+		return ""
+	}
+
+	return termenv.CSI + sequence + "m"
+}
+
+// DimColor scales the brightness of a hex palette color, where 1 keeps the color
+// and 0 turns it black. Colors that are not hex are returned unchanged.
+func DimColor(hex string, brightness float64) string {
+	parsed, err := ParseHexColor(hex)
+	if err != nil {
+		return hex
+	}
+
+	brightness = max(0, min(1, brightness))
+	scale := func(channel uint8) uint8 {
+		return uint8(math.Round(float64(channel) * brightness))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", scale(parsed.R), scale(parsed.G), scale(parsed.B))
+}
+
+// ParseHexColor parses a color in the #RRGGBB form
+func ParseHexColor(hex string) (color.RGBA, error) {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b); err != nil || len(hex) != 7 {
+		return color.RGBA{}, fmt.Errorf("'%s' is not a #RRGGBB color", hex)
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 0xFF}, nil
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fireworks tuning, in terminal cells and seconds
+const (
+	burstInterval     = 450 * time.Millisecond
+	particlesPerBurst = 28
+	particleGravity   = 14.0
+	maxParticleLife   = 1600 * time.Millisecond
+)
+
+// bellSequence rings the terminal bell
+const bellSequence = "\a"
+
+// particleGlyphs are the characters particles are drawn with
+var particleGlyphs = []rune{'*', '+', '.', 'o', '✶'}
+
+// particle is a single spark of a firework burst
+type particle struct {
+	x, y     float64
+	vx, vy   float64
+	diesAt   time.Time
+	glyph    rune
+	colorHex string
+}
+
+// celebration is the fireworks effect shown when a milestone is crossed
+type celebration struct {
+	milestone float64
+	currency  string
+	startedAt time.Time
+	updatedAt time.Time
+	nextBurst time.Time
+	duration  time.Duration
+	colors    []string
+	particles []particle
+	rng       *rand.Rand
+}
+
+// newCelebration creates a celebration for the given milestone
+func newCelebration(milestone float64, currency string, duration time.Duration, colors []string, now time.Time) *celebration {
+	if len(colors) == 0 {
+		colors = []string{"#FFFFFF"}
+	}
+	seed := uint64(now.UnixNano())
+	return &celebration{
+		milestone: milestone,
+		currency:  currency,
+		startedAt: now,
+		updatedAt: now,
+		nextBurst: now,
+		duration:  duration,
+		colors:    colors,
+		rng:       rand.New(rand.NewPCG(seed, seed>>32)),
+	}
+}
+
+// startCelebration celebrates a crossed milestone, ringing the terminal bell in
+// the next frame and returning the command for the history entry
+func (m *Model) startCelebration(milestone float64, cost *domain.CostData, now time.Time) tea.Cmd {
+	config := m.config.GetMilestoneConfig()
+
+	var colors []string
+	if animationConfig := m.config.GetAnimationConfig(); animationConfig != nil {
+		colors = animationConfig.Colors
+	}
+	m.celebration = newCelebration(milestone, cost.Currency, config.Duration, colors, now)
+
+	m.ringing = config.Bell && !m.muted
+
+	if m.history == nil {
+		return nil
+	}
+	history := m.history
+	entry := domain.HistoryEntry{
+		Time:     now,
+		Type:     domain.HistoryEventMilestone,
+		Amount:   milestone,
+		Cost:     cost.TotalCost,
+		Currency: cost.Currency,
+	}
+	return func() tea.Msg {
+		// A failed write must not interrupt the display
+		_ = history.Record(context.Background(), entry)
+		return nil
+	}
+}
+
+// checkMilestone starts a celebration when the new cost crossed a milestone
+func (m *Model) checkMilestone(previous, next *domain.CostData, now time.Time) tea.Cmd {
+	if previous == nil || next == nil || previous.Currency != next.Currency {
+		return nil
+	}
+
+	milestone, crossed := domain.CrossedMilestone(m.config.GetMilestoneConfig(), previous.TotalCost, next.TotalCost)
+	if !crossed {
+		return nil
+	}
+
+	return m.startCelebration(milestone, next, now)
+}
+
+// updateCelebration advances the fireworks and ends them once their time is up
+func (m *Model) updateCelebration(now time.Time, width, height int) {
+	if m.celebration == nil {
+		return
+	}
+
+	if m.celebration.done(now) {
+		m.celebration = nil
+		return
+	}
+
+	m.celebration.update(now, width, height)
+}
+
+// done reports whether the celebration is over
+func (c *celebration) done(now time.Time) bool {
+	return now.Sub(c.startedAt) >= c.duration
+}
+
+// update launches new bursts and moves the particles to the given time
+func (c *celebration) update(now time.Time, width, height int) {
+	dt := now.Sub(c.updatedAt).Seconds()
+	c.updatedAt = now
+
+	alive := c.particles[:0]
+	for _, p := range c.particles {
+		p.vy += particleGravity * dt
+		p.x += p.vx * dt
+		p.y += p.vy * dt
+		if now.Before(p.diesAt) && p.x >= 0 && p.x < float64(width) && p.y >= 0 && p.y < float64(height) {
+			alive = append(alive, p)
+		}
+	}
+	c.particles = alive
+
+	// Stop launching once new sparks would outlive the celebration
+	for !now.Before(c.nextBurst) && c.nextBurst.Add(maxParticleLife).Before(c.startedAt.Add(c.duration)) {
+		c.burst(c.nextBurst, width, height)
+		c.nextBurst = c.nextBurst.Add(burstInterval)
+	}
+}
+
+// burst launches a firework at a random spot in the upper part of the field
+func (c *celebration) burst(at time.Time, width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	centerX := float64(width) * (0.15 + 0.7*c.rng.Float64())
+	centerY := float64(height) * (0.15 + 0.35*c.rng.Float64())
+	colorHex := c.colors[c.rng.IntN(len(c.colors))]
+
+	for i := 0; i < particlesPerBurst; i++ {
+		angle := 2 * math.Pi * float64(i) / particlesPerBurst
+		speed := 5 + 7*c.rng.Float64()
+		life := maxParticleLife/2 + time.Duration(c.rng.Int64N(int64(maxParticleLife/2)))
+		c.particles = append(c.particles, particle{
+			x:        centerX,
+			y:        centerY,
+			vx:       2 * speed * math.Cos(angle), // Cells are about twice as tall as wide
+			vy:       speed * math.Sin(angle),
+			diesAt:   at.Add(life),
+			glyph:    particleGlyphs[c.rng.IntN(len(particleGlyphs))],
+			colorHex: colorHex,
+		})
+	}
+}
+
+// cells renders the particles as styled overlay cells
+func (c *celebration) cells(profile domain.ColorProfile) []overlayCell {
+	renderer := terminal.NewRenderer(profile)
+	styles := make(map[string]lipgloss.Style)
+
+	cells := make([]overlayCell, 0, len(c.particles))
+	for _, p := range c.particles {
+		style, exists := styles[p.colorHex]
+		if !exists {
+			style = renderer.NewStyle().Foreground(terminal.AdaptColor(p.colorHex, profile))
+			styles[p.colorHex] = style
+		}
+		cells = append(cells, overlayCell{
+			x:    int(p.x),
+			y:    int(p.y),
+			text: style.Render(string(p.glyph)),
+		})
+	}
+
+	return cells
+}
+
+// renderMilestoneBanner renders the banner shown during a celebration, or an empty string
+func (m *Model) renderMilestoneBanner(profile domain.ColorProfile, format domain.NumberFormat) string {
+	if m.celebration == nil {
+		return ""
+	}
+
+	text := "✶ " + domain.FormatCost(m.celebration.milestone, m.celebration.currency, format) + " milestone! ✶"
+	bannerStyle := terminal.NewRenderer(profile).NewStyle().Bold(true).Foreground(terminal.AdaptColor("#FFD700", profile))
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, bannerStyle.Render(text))
+}
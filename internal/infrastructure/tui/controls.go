@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+)
+
+// Animation speed limits for the live speed controls
+const (
+	minAnimationSpeed = 20 * time.Millisecond
+	maxAnimationSpeed = 2 * time.Second
+)
+
+// noticeDuration is how long a notice, such as the newly selected display, stays visible
+const noticeDuration = 2 * time.Second
+
+// togglePause pauses or resumes the animation
+func (m *Model) togglePause() {
+	if m.animator.IsPaused() {
+		_ = m.animator.StartAnimation(m.ctx)
+	} else {
+		_ = m.animator.StopAnimation(m.ctx)
+	}
+}
+
+// cyclePattern switches to the next (or previous) supported animation pattern
+func (m *Model) cyclePattern(step int) {
+	patterns := m.animator.SupportedPatterns()
+	if len(patterns) == 0 {
+		return
+	}
+
+	config, err := m.animator.GetAnimationConfig(m.ctx)
+	if err != nil {
+		return
+	}
+
+	current := 0
+	for i, pattern := range patterns {
+		if pattern == config.Pattern {
+			current = i
+			break
+		}
+	}
+
+	config.Pattern = patterns[wrapIndex(current+step, len(patterns))]
+	if err := m.animator.UpdateAnimationConfig(m.ctx, config); err == nil {
+		m.settingsChanged = true
+	}
+}
+
+// changeSpeed scales the animation frame interval, clamped to sensible limits.
+// A factor below 1 makes the animation faster.
+func (m *Model) changeSpeed(factor float64) {
+	config, err := m.animator.GetAnimationConfig(m.ctx)
+	if err != nil {
+		return
+	}
+
+	speed := time.Duration(float64(config.Speed) * factor).Round(time.Millisecond)
+	speed = max(minAnimationSpeed, min(maxAnimationSpeed, speed))
+	if speed == config.Speed {
+		return
+	}
+
+	config.Speed = speed
+	if err := m.animator.UpdateAnimationConfig(m.ctx, config); err == nil {
+		m.settingsChanged = true
+	}
+}
+
+// cycleTheme switches to the next (or previous) theme, skipping themes that fail validation
+func (m *Model) cycleTheme(step int) {
+	themes := m.config.ListThemes()
+	if len(themes) == 0 {
+		return
+	}
+
+	config, err := m.animator.GetAnimationConfig(m.ctx)
+	if err != nil {
+		return
+	}
+
+	currentName := config.Theme
+	if currentName == "" {
+		currentName = core.DefaultThemeName
+	}
+
+	current := 0
+	for i, theme := range themes {
+		if strings.EqualFold(theme.Name, currentName) {
+			current = i
+			break
+		}
+	}
+
+	for offset := 1; offset < len(themes); offset++ {
+		theme := themes[wrapIndex(current+step*offset, len(themes))]
+		next := *config
+		next.Colors = theme.Colors
+		next.Theme = theme.Name
+		if err := m.animator.UpdateAnimationConfig(m.ctx, &next); err == nil {
+			m.settingsChanged = true
+			return
+		}
+	}
+}
+
+// cycleDisplay switches to the next (or previous) display in the configured order
+func (m *Model) cycleDisplay(step int) {
+	names := m.config.DisplayNames()
+	current := max(0, slices.Index(names, m.config.GetConfig().Plugins.Display))
+	m.selectDisplay(wrapIndex(current+step, len(names)))
+}
+
+// selectDisplay switches to the display at the given position. The animation,
+// transition and report view carry over since they belong to the model.
+func (m *Model) selectDisplay(index int) {
+	names := m.config.DisplayNames()
+	if index < 0 || index >= len(names) {
+		return
+	}
+
+	if err := m.registry.SetActiveDisplay(names[index]); err != nil {
+		m.showNotice(err.Error())
+		return
+	}
+	m.showNotice(fmt.Sprintf("Display %d/%d · %s", index+1, len(names), names[index]))
+}
+
+// showNotice shows a short message in the footer for a moment. Kiosk mode shows no notices.
+func (m *Model) showNotice(text string) {
+	if m.kiosk() != nil {
+		return
+	}
+	m.notice = text
+	m.noticeUntil = m.clock().Add(noticeDuration)
+}
+
+// wrapIndex wraps an index into the range [0, length)
+func wrapIndex(index, length int) int {
+	return ((index % length) + length) % length
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"errors"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// errorHints tells how to fix each class of data source failure, checked in order
+var errorHints = []struct {
+	class error
+	hint  string
+}{
+	{domain.ErrNodeMissing, "Install Node.js 18 or later so that npx can run ccusage."},
+	{domain.ErrBinaryNotFound, "Install ccusage with 'npm install -g ccusage', or set datasource.ccusage_path to where it is."},
+	{domain.ErrPermissionDenied, "Make sure ccusage is executable and your user can read the Claude data directory (~/.claude)."},
+	{domain.ErrTimeout, "The first npx run downloads ccusage and can be slow. Try again, or raise datasource.timeout."},
+	{domain.ErrNoUsageData, "No Claude usage logs were found in ~/.claude/projects. Set CLAUDE_CONFIG_DIR if they are elsewhere."},
+	{domain.ErrParseFailure, "This ccusage version prints a report ccugorg cannot read. Update ccusage, or pin a known version."},
+	{domain.ErrCommandFailed, "Run 'npx ccusage daily --json' in a terminal to see the full output."},
+}
+
+// errorHint returns how to fix a data source failure, or an empty string when it is unclassified
+func errorHint(err error) string {
+	for _, h := range errorHints {
+		if errors.Is(err, h.class) {
+			return h.hint
+		}
+	}
+	return ""
+}
+
+// renderError renders a failed fetch with its remedy, followed by the prompt
+func renderError(err error, prompt string) string {
+	text := "Error: " + err.Error() + "\n\n"
+	if hint := errorHint(err); hint != "" {
+		text += "Hint: " + hint + "\n\n"
+	}
+	return text + prompt + "\n"
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderHelp renders the help overlay listing key bindings and current settings
+func (m *Model) renderHelp(profile domain.ColorProfile) string {
+	renderer := terminal.NewRenderer(profile)
+	titleStyle := renderer.NewStyle().Bold(true)
+	keyStyle := renderer.NewStyle().Bold(true).Foreground(terminal.AdaptColor("#FFD700", profile))
+	descStyle := renderer.NewStyle().Faint(true)
+
+	// Key bindings, one column per group
+	var columns []string
+	for _, group := range m.keys.FullHelp() {
+		var keys, descs []string
+		for _, binding := range group {
+			if !binding.Enabled() {
+				continue
+			}
+			keys = append(keys, keyStyle.Render(binding.Help().Key))
+			descs = append(descs, descStyle.Render(binding.Help().Desc))
+		}
+		if len(columns) > 0 {
+			columns = append(columns, "    ")
+		}
+		columns = append(columns, lipgloss.JoinHorizontal(lipgloss.Top, strings.Join(keys, "\n"), "  ", strings.Join(descs, "\n")))
+	}
+
+	// Current settings
+	var settings []string
+	if config := m.config.GetAnimationConfig(); config != nil {
+		state := "running"
+		if m.animator.IsPaused() {
+			state = "paused"
+		}
+		if !config.Enabled {
+			state = "disabled"
+		}
+		theme := config.Theme
+		if theme == "" {
+			theme = core.DefaultThemeName
+		}
+		settings = append(settings,
+			fmt.Sprintf("Animation  %s", state),
+			fmt.Sprintf("Pattern    %s", config.Pattern),
+			fmt.Sprintf("Speed      %s", config.Speed),
+			fmt.Sprintf("Theme      %s", theme),
+		)
+	}
+	settings = append(settings, fmt.Sprintf("Display    %s", m.config.GetConfig().Plugins.Display))
+	if m.currentCost != nil {
+		settings = append(settings, fmt.Sprintf("Currency   %s", m.currentCost.Currency))
+	}
+	settings = append(settings, fmt.Sprintf("Colors     %s", profile))
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Key bindings"),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, columns...),
+		"",
+		titleStyle.Render("Settings"),
+		"",
+		strings.Join(settings, "\n"),
+	)
+
+	return renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1, 2).
+		Render(content)
+}
+
+// renderHintBar renders the one-line key hint shown at startup
+func (m *Model) renderHintBar(profile domain.ColorProfile) string {
+	hints := make([]string, 0, len(m.keys.ShortHelp()))
+	for _, binding := range m.keys.ShortHelp() {
+		if binding.Enabled() {
+			hints = append(hints, bindingHint(binding))
+		}
+	}
+
+	hintStyle := terminal.NewRenderer(profile).NewStyle().Faint(true)
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, hintStyle.Render(strings.Join(hints, " · ")))
+}
+
+// bindingHint formats a binding as "key action"
+func bindingHint(binding key.Binding) string {
+	return binding.Help().Key + " " + binding.Help().Desc
+}
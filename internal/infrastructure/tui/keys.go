@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the key bindings of the TUI
+type KeyMap struct {
+	Quit        key.Binding
+	Refresh     key.Binding
+	Pause       key.Binding
+	NextPattern key.Binding
+	PrevPattern key.Binding
+	Faster      key.Binding
+	Slower      key.Binding
+	NextTheme   key.Binding
+	PrevTheme   key.Binding
+	Period      key.Binding
+	Details     key.Binding
+	NextDisplay key.Binding
+	PrevDisplay key.Binding
+	Display     key.Binding // Selects the display at the position of the pressed key
+	Help        key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		Refresh:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		Pause:       key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "pause/resume")),
+		NextPattern: key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "next pattern")),
+		PrevPattern: key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "previous pattern")),
+		Faster:      key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "faster")),
+		Slower:      key.NewBinding(key.WithKeys("-", "_"), key.WithHelp("-", "slower")),
+		NextTheme:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "next theme")),
+		PrevTheme:   key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "previous theme")),
+		Period:      key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "report period")),
+		Details:     key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "breakdown panel")),
+		NextDisplay: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next display")),
+		PrevDisplay: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous display")),
+		Display:     key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "select display")),
+		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+	}
+}
+
+// NewKeyMap returns the default key bindings with the given overrides applied.
+// Overrides map action names (e.g. "next_theme") to the keys that trigger them.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	keyMap := DefaultKeyMap()
+	actions := keyMap.actions()
+
+	for action, keys := range overrides {
+		binding, exists := actions[action]
+		if !exists {
+			return keyMap, fmt.Errorf("unknown key action '%s'. Valid actions: %s", action, strings.Join(KeyActions(), ", "))
+		}
+		if len(keys) == 0 {
+			return keyMap, fmt.Errorf("no keys bound to action '%s'", action)
+		}
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = keyName(k)
+		}
+		binding.SetKeys(names...)
+		binding.SetHelp(keyLabel(names[0]), binding.Help().Desc)
+	}
+
+	// Every key must trigger a single action
+	owners := make(map[string]string)
+	for _, action := range KeyActions() {
+		for _, k := range actions[action].Keys() {
+			if owner, exists := owners[k]; exists {
+				return keyMap, fmt.Errorf("key '%s' is bound to both '%s' and '%s'", keyLabel(k), owner, action)
+			}
+			owners[k] = action
+		}
+	}
+
+	return keyMap, nil
+}
+
+// KeyActions returns the names of all rebindable actions, sorted
+func KeyActions() []string {
+	keyMap := DefaultKeyMap()
+	actions := make([]string, 0, len(keyMap.actions()))
+	for action := range keyMap.actions() {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// ShortHelp returns the bindings shown in the hint bar
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Help, k.Pause, k.NextTheme, k.Quit}
+}
+
+// FullHelp returns all bindings grouped for the help overlay
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Pause, k.NextPattern, k.PrevPattern, k.Faster, k.Slower},
+		{k.NextTheme, k.PrevTheme, k.Period, k.Details, k.Refresh},
+		{k.NextDisplay, k.PrevDisplay, k.Display, k.Help, k.Quit},
+	}
+}
+
+// actions maps action names to the bindings they configure
+func (k *KeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":         &k.Quit,
+		"refresh":      &k.Refresh,
+		"pause":        &k.Pause,
+		"next_pattern": &k.NextPattern,
+		"prev_pattern": &k.PrevPattern,
+		"faster":       &k.Faster,
+		"slower":       &k.Slower,
+		"next_theme":   &k.NextTheme,
+		"prev_theme":   &k.PrevTheme,
+		"period":       &k.Period,
+		"details":      &k.Details,
+		"next_display": &k.NextDisplay,
+		"prev_display": &k.PrevDisplay,
+		"display":      &k.Display,
+		"help":         &k.Help,
+	}
+}
+
+// keyName converts a configured key into the name reported by key messages
+func keyName(k string) string {
+	if k == "space" {
+		return " "
+	}
+	return k
+}
+
+// keyLabel returns the display label of a key
+func keyLabel(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Messages for the kiosk timers
+type (
+	rotateMsg    struct{}
+	reconnectMsg struct{}
+)
+
+// kiosk returns the kiosk settings, or nil when kiosk mode is off
+func (m *Model) kiosk() *domain.KioskConfig {
+	config := m.config.GetKioskConfig()
+	if !config.Enabled {
+		return nil
+	}
+	return &config
+}
+
+// scheduleRotation waits for the next report period or display change
+func (m *Model) scheduleRotation() tea.Cmd {
+	kiosk := m.kiosk()
+	if kiosk == nil || kiosk.RotateInterval <= 0 {
+		return nil
+	}
+
+	m.rotating = true
+	return tea.Tick(kiosk.RotateInterval, func(time.Time) tea.Msg {
+		return rotateMsg{}
+	})
+}
+
+// rotate moves to the next report period, and on to the next display
+// once every period has been shown
+func (m *Model) rotate() {
+	if m.currentCost == nil || len(m.currentCost.Daily) == 0 {
+		m.cycleDisplay(1)
+		return
+	}
+
+	m.cyclePeriod(1)
+	if m.period == domain.ReportPeriods()[0] {
+		m.cycleDisplay(1)
+	}
+}
+
+// reconnect keeps showing the last cost data after a failed fetch and tries again later
+func (m *Model) reconnect(err error) tea.Cmd {
+	if m.currentCost == nil {
+		m.error = err
+	}
+	m.reconnecting = true
+	m.isLoading = false
+
+	return tea.Tick(m.kiosk().RetryInterval, func(time.Time) tea.Msg {
+		return reconnectMsg{}
+	})
+}
+
+// reconnectPrompt tells how long until the next attempt to fetch cost data
+func (m *Model) reconnectPrompt() string {
+	return fmt.Sprintf("Reconnecting every %s...", m.kiosk().RetryInterval)
+}
+
+// dimColors darkens the palette during the kiosk quiet hours
+func (m *Model) dimColors(colors []string) []string {
+	kiosk := m.kiosk()
+	if kiosk == nil || !kiosk.QuietHours.Contains(m.clock()) {
+		return colors
+	}
+
+	dimmed := make([]string, len(colors))
+	for i, color := range colors {
+		dimmed[i] = terminal.DimColor(color, kiosk.QuietHours.Brightness)
+	}
+	return dimmed
+}
+
+// driftMargin returns how far the art may drift within the given size,
+// in columns and rows. Terminal cells are about twice as tall as wide,
+// so the art drifts half as many rows.
+func (m *Model) driftMargin(width, height int) (int, int) {
+	kiosk := m.kiosk()
+	if kiosk == nil || kiosk.DriftInterval <= 0 {
+		return 0, 0
+	}
+
+	columns := min(kiosk.DriftRange, width/4)
+	rows := min((kiosk.DriftRange+1)/2, height/4)
+	return max(0, columns), max(0, rows)
+}
+
+// driftOffset returns the current offset of the art within the drift margin.
+// The art sweeps back and forth one column per interval, moving a row after every sweep.
+func (m *Model) driftOffset(columns, rows int) (int, int) {
+	step := int(m.clock().Sub(m.startedAt) / m.kiosk().DriftInterval)
+	if columns == 0 {
+		return 0, bounce(step, rows)
+	}
+	return bounce(step, columns), bounce(step/(2*columns), rows)
+}
+
+// bounce moves back and forth between 0 and limit, one step at a time
+func bounce(step, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+
+	position := step % (2 * limit)
+	if position > limit {
+		position = 2*limit - position
+	}
+	return position
+}
+
+// shiftBlock moves a block of text right and down by padding it with blank cells
+func shiftBlock(text string, x, y int) string {
+	lines := strings.Split(text, "\n")
+	padding := strings.Repeat(" ", x)
+	for i := range lines {
+		lines[i] = padding + lines[i]
+	}
+	return strings.Join(append(slices.Repeat([]string{""}, y), lines...), "\n")
+}
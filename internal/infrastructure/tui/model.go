@@ -2,27 +2,72 @@ package tui
 
 import (
 	"context"
-	"strconv"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
+// hintDuration is how long the key hint bar stays visible after startup
+const hintDuration = 5 * time.Second
+
+// staleRefetchInterval is the wait before fetching again while stale data is refreshed
+const staleRefetchInterval = time.Second
+
 // Model represents the TUI application model
 type Model struct {
 	ctx         context.Context
 	registry    *core.PluginRegistry
 	config      *core.ConfigManager
+	converter   interfaces.CurrencyConverter
+	history     interfaces.HistoryStore
+	costCache   interfaces.CostCacheStore
+	muted       bool // Never ring the terminal bell
+	ringing     bool // Ring the terminal bell in the next frame
+	animator    *core.AnimationController
+	keys        KeyMap
 	width       int
 	height      int
 	frameCount  int
 	currentCost *domain.CostData
+	transition  *costTransition
+	celebration *celebration
+	now         time.Time
 	lastUpdate  time.Time
+	fetch       domain.FetchReport
 	error       error
+	errorCount  int
 	isLoading   bool
 	isQuitting  bool
+	showHelp    bool
+	showHint    bool
+	notice      string
+	noticeUntil time.Time
+
+	// Kiosk state
+	startedAt    time.Time
+	rotating     bool
+	reconnecting bool
+
+	// Report view and mouse state
+	period        domain.ReportPeriod
+	selectedModel string
+	showDetails   bool
+	sparkWindow   int
+	mouseX        int
+	mouseY        int
+	hovering      bool
+
+	settingsChanged bool
 }
 
 // NewModel creates a new TUI model
@@ -31,17 +76,81 @@ func NewModel(ctx context.Context, registry *core.PluginRegistry, config *core.C
 		ctx:        ctx,
 		registry:   registry,
 		config:     config,
+		animator:   core.NewAnimationController(registry, config),
+		keys:       DefaultKeyMap(),
 		frameCount: 0,
 		isLoading:  true,
+		showHint:   !config.GetKioskConfig().Enabled,
+		startedAt:  time.Now(),
+
+		period:      domain.PeriodAll,
+		showDetails: true,
+		sparkWindow: 14,
+	}
+}
+
+// SetHistoryStore sets the store that records milestones
+func (m *Model) SetHistoryStore(history interfaces.HistoryStore) {
+	m.history = history
+}
+
+// SetCostCache sets the store that keeps the latest cost data for the next startup
+func (m *Model) SetCostCache(costCache interfaces.CostCacheStore) {
+	m.costCache = costCache
+}
+
+// SetKeyMap sets the key bindings of the model
+func (m *Model) SetKeyMap(keys KeyMap) {
+	m.keys = keys
+}
+
+// SetCurrencyConverter sets the converter applied to fetched cost data
+func (m *Model) SetCurrencyConverter(converter interfaces.CurrencyConverter) {
+	m.converter = converter
+}
+
+// Status returns the current status of the application, including the data source that served the cost
+func (m *Model) Status() *interfaces.AppStatus {
+	status := &interfaces.AppStatus{
+		IsRunning:      !m.isQuitting,
+		LastUpdate:     m.lastUpdate,
+		CurrentCost:    m.currentCost,
+		ErrorCount:     m.errorCount,
+		DataSource:     m.fetch.Source,
+		FallbackReason: m.fetch.Reason,
+	}
+	if m.error != nil {
+		status.LastError = m.error.Error()
+	}
+
+	for _, plugin := range m.registry.PluginStatuses() {
+		if plugin.State.IsUsable() {
+			status.ActivePlugins = append(status.ActivePlugins, plugin.Name)
+		}
 	}
+
+	return status
+}
+
+// SettingsChanged reports whether animation settings were changed with the keyboard
+func (m *Model) SettingsChanged() bool {
+	return m.settingsChanged
 }
 
 // Init initializes the TUI model
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
+		m.loadCachedCost(),
 		m.fetchCostData(),
 		m.tick(),
-	)
+		tea.Tick(hintDuration, func(time.Time) tea.Msg {
+			return hideHintMsg{}
+		}),
+	}
+	if m.kiosk() != nil {
+		cmds = append(cmds, tea.HideCursor)
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles TUI updates
@@ -53,29 +162,115 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			m.isQuitting = true
 			return m, tea.Quit
-		case "r":
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+		case msg.Type == tea.KeyEsc && m.showHelp:
+			m.showHelp = false
+		case key.Matches(msg, m.keys.Refresh):
 			// Refresh data
 			return m, m.fetchCostData()
+		case key.Matches(msg, m.keys.Pause):
+			m.togglePause()
+		case key.Matches(msg, m.keys.NextPattern):
+			m.cyclePattern(1)
+		case key.Matches(msg, m.keys.PrevPattern):
+			m.cyclePattern(-1)
+		case key.Matches(msg, m.keys.Faster):
+			m.changeSpeed(0.8)
+		case key.Matches(msg, m.keys.Slower):
+			m.changeSpeed(1.25)
+		case key.Matches(msg, m.keys.NextTheme):
+			m.cycleTheme(1)
+		case key.Matches(msg, m.keys.PrevTheme):
+			m.cycleTheme(-1)
+		case key.Matches(msg, m.keys.Period):
+			m.cyclePeriod(1)
+		case key.Matches(msg, m.keys.Details):
+			m.showDetails = !m.showDetails
+		case key.Matches(msg, m.keys.NextDisplay):
+			m.cycleDisplay(1)
+		case key.Matches(msg, m.keys.PrevDisplay):
+			m.cycleDisplay(-1)
+		case key.Matches(msg, m.keys.Display):
+			m.selectDisplay(slices.Index(m.keys.Display.Keys(), msg.String()))
 		}
 
+	case tea.MouseMsg:
+		m.handleMouse(msg)
+		return m, nil
+
 	case costDataMsg:
+		if msg.err != nil && m.kiosk() != nil {
+			return m, m.reconnect(msg.err)
+		}
+
+		now := time.Now()
+		var cmd tea.Cmd
+		if msg.err == nil {
+			m.startTransition(m.reportCost(m.currentCost), m.reportCost(msg.costData), now, true)
+			cmd = m.checkMilestone(m.currentCost, msg.costData, now)
+			if !m.rotating {
+				cmd = tea.Batch(cmd, m.scheduleRotation())
+			}
+			if msg.costData.Stale {
+				cmd = tea.Batch(cmd, tea.Tick(staleRefetchInterval, func(time.Time) tea.Msg {
+					return refetchMsg{}
+				}))
+			}
+		}
+		m.updateTransition(now)
 		m.currentCost = msg.costData
+		m.fetch = msg.report
 		m.error = msg.err
-		m.lastUpdate = time.Now()
+		if msg.err != nil {
+			m.errorCount++
+		}
+		// Stale data is what was last fetched, so keep its time
+		if msg.err != nil || !msg.costData.Stale || m.lastUpdate.IsZero() {
+			m.lastUpdate = now
+		}
 		m.isLoading = false
+		m.reconnecting = false
+		return m, cmd
+
+	case rotateMsg:
+		m.rotate()
+		return m, m.scheduleRotation()
+
+	case reconnectMsg, refetchMsg:
+		return m, m.fetchCostData()
+
+	case cachedCostMsg:
+		// The cached data only fills in until the first fetch completes
+		if m.isLoading {
+			m.currentCost = msg.costData
+			m.fetch = domain.FetchReport{Source: msg.source}
+			m.lastUpdate = msg.savedAt
+			m.isLoading = false
+		}
 		return m, nil
 
 	case tickMsg:
-		m.frameCount++
+		// The bell was part of the frame drawn since the last tick
+		m.ringing = false
+		m.updateTransition(msg.time)
+		m.updateCelebration(msg.time, m.width, m.height)
+		if !m.animator.IsPaused() {
+			m.frameCount++
+		}
 		if m.isQuitting {
 			return m, nil
 		}
 		return m, m.tick()
 
+	case hideHintMsg:
+		m.showHint = false
+		return m, nil
+
 	case errorMsg:
 		m.error = msg.err
 		m.isLoading = false
@@ -96,94 +291,232 @@ func (m *Model) View() string {
 	}
 
 	if m.error != nil {
-		return "Error: " + m.error.Error() + "\n\nPress 'r' to retry or 'q' to quit.\n"
+		if m.kiosk() != nil {
+			return renderError(m.error, m.reconnectPrompt())
+		}
+		return renderError(m.error, m.keyPrompt("retry"))
 	}
 
 	if m.currentCost == nil {
-		return "No cost data available.\n\nPress 'r' to refresh or 'q' to quit.\n"
-	}
-
-	// Get active plugins
-	animationPlugin, err := m.registry.GetActiveAnimation()
-	if err != nil {
-		return "Error getting animation plugin: " + err.Error() + "\n"
+		return "No cost data available.\n\n" + m.keyPrompt("refresh") + "\n"
 	}
 
+	// Get active display plugin
 	displayPlugin, err := m.registry.GetActiveDisplay()
 	if err != nil {
 		return "Error getting display plugin: " + err.Error() + "\n"
 	}
 
-	// Generate animation frame
-	animationConfig := m.config.GetAnimationConfig()
-	costText := "$" + formatFloat(m.currentCost.TotalCost)
+	// Create display config sized to the terminal
+	displayConfig := m.config.GetDisplayConfig()
+	if displayConfig == nil {
+		return "Error: no display configuration available\n"
+	}
+	layout := m.layoutScreen(displayPlugin, displayConfig)
+	displayConfig.Size.Width = m.width
+	displayConfig.Size.Height = layout.displayHeight
+
+	// In kiosk mode the art is rendered slightly smaller and drifts within the margin
+	driftColumns, driftRows := m.driftMargin(displayConfig.Size.Width, displayConfig.Size.Height)
 
-	animationFrame, err := animationPlugin.GenerateFrame(m.ctx, costText, m.frameCount, animationConfig)
+	// Generate animation frame for the displayed (possibly counting up) cost
+	displayedCost := m.displayedCost()
+	costText := domain.FormatCost(displayedCost.TotalCost, displayedCost.Currency, displayConfig.Format)
+
+	animationFrame, err := m.animator.GenerateAnimationFrame(m.ctx, costText, m.frameCount)
 	if err != nil {
 		return "Error generating animation: " + err.Error() + "\n"
 	}
+	animationFrame.Colors = m.dimColors(animationFrame.Colors)
 
 	// Create display data
-	displayConfig := m.config.GetDisplayConfig()
-	if displayConfig != nil {
-		displayConfig.Size.Width = m.width
-		displayConfig.Size.Height = m.height
-	}
-
 	displayData := &domain.DisplayData{
-		Cost:        m.currentCost,
+		Cost:        displayedCost,
+		FullCost:    m.currentCost,
 		Animation:   animationFrame,
 		Config:      displayConfig,
 		LastUpdated: m.lastUpdate,
+		Highlight:   m.highlightedChars(costText, displayConfig.Format),
+		Now:         m.clock(),
 	}
 
 	// Render display
+	displayConfig.Size.Width -= driftColumns
+	displayConfig.Size.Height -= driftRows
 	output, err := displayPlugin.Render(m.ctx, displayData)
 	if err != nil {
 		return "Error rendering display: " + err.Error() + "\n"
 	}
 
+	if driftColumns > 0 || driftRows > 0 {
+		x, y := m.driftOffset(driftColumns, driftRows)
+		displayConfig.Size.Width += driftColumns
+		displayConfig.Size.Height += driftRows
+		output = fitHeight(shiftBlock(output, x, y), displayConfig.Size.Height)
+	}
+
+	if m.celebration != nil {
+		output = placeCells(output, m.celebration.cells(displayConfig.ColorProfile), m.width, displayConfig.Size.Height)
+	}
+
+	if layout.panel != nil {
+		output = fitHeight(output, displayConfig.Size.Height) + "\n" + m.renderPanel(layout, displayConfig.ColorProfile, displayConfig.Format)
+	}
+
+	if m.showHelp {
+		output = placeOverlay(output, m.renderHelp(displayConfig.ColorProfile), m.width, displayConfig.Size.Height)
+	}
+
+	if layout.footer != "" {
+		output += "\n" + layout.footer
+	}
+
+	// The renderer writes the bell along with the frame, which keeps it in order with the screen
+	if m.ringing {
+		output += bellSequence
+	}
+
 	return output
 }
 
+// keyPrompt describes the refresh and quit keys, e.g. "Press 'r' to retry or 'q' to quit."
+func (m *Model) keyPrompt(refreshAction string) string {
+	return fmt.Sprintf("Press '%s' to %s or '%s' to quit.", m.keys.Refresh.Help().Key, refreshAction, m.keys.Quit.Help().Key)
+}
+
+// renderFooter renders the status footer, or an empty string when there is nothing to show
+func (m *Model) renderFooter(displayConfig *domain.DisplayConfig) string {
+	profile := displayConfig.ColorProfile
+
+	var lines []string
+	if banner := m.renderMilestoneBanner(profile, displayConfig.Format); banner != "" {
+		lines = append(lines, banner)
+	}
+
+	if badge := m.renderDeltaBadge(profile, displayConfig.Format); badge != "" {
+		lines = append(lines, badge)
+	}
+
+	if m.notice != "" && m.clock().Before(m.noticeUntil) {
+		noticeStyle := terminal.NewRenderer(profile).NewStyle().Bold(true)
+		lines = append(lines, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, noticeStyle.Render(m.notice)))
+	}
+
+	if m.showHint && !m.showHelp {
+		lines = append(lines, m.renderHintBar(profile))
+	}
+
+	if m.reconnecting {
+		reconnectStyle := terminal.NewRenderer(profile).NewStyle().Faint(true)
+		lines = append(lines, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, reconnectStyle.Render("Data unavailable · "+m.reconnectPrompt())))
+	}
+
+	if m.currentCost.Stale {
+		staleStyle := terminal.NewRenderer(profile).NewStyle().Faint(true)
+		text := "Cached data from " + formatUpdateTime(m.lastUpdate, m.clock()) + " · refreshing"
+		lines = append(lines, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, staleStyle.Render(text)))
+	}
+
+	if m.fetch.IsFallback() {
+		sourceStyle := terminal.NewRenderer(profile).NewStyle().Faint(true)
+		text := ansi.Truncate("Data from "+m.fetch.Source+" · "+m.fetch.Reason, m.width, "…")
+		lines = append(lines, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, sourceStyle.Render(text)))
+	}
+
+	if conversion := m.currentCost.Conversion; conversion != nil {
+		text := fmt.Sprintf("1 %s = %s %s", conversion.From, domain.FormatNumber(conversion.Rate, 4, domain.DefaultNumberFormat()), conversion.To)
+		if !conversion.RateDate.IsZero() {
+			text += " · rates as of " + conversion.RateDate.Format("2006-01-02")
+		}
+
+		footerStyle := terminal.NewRenderer(profile).NewStyle().Faint(true)
+		lines = append(lines, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, footerStyle.Render(text)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // Messages for the TUI update loop
 type (
 	costDataMsg struct {
 		costData *domain.CostData
+		report   domain.FetchReport
 		err      error
 	}
-	tickMsg  struct{}
-	errorMsg struct{ err error }
+	cachedCostMsg struct {
+		costData *domain.CostData
+		source   string
+		savedAt  time.Time
+	}
+	refetchMsg  struct{}
+	tickMsg     struct{ time time.Time }
+	hideHintMsg struct{}
+	errorMsg    struct{ err error }
 )
 
-// fetchCostData fetches cost data from the active data source plugin
+// fetchCostData fetches cost data from the data source chain, keeping fresh data for the next startup
 func (m *Model) fetchCostData() tea.Cmd {
 	return func() tea.Msg {
-		dataSourcePlugin, err := m.registry.GetActiveDataSource()
-		if err != nil {
-			return costDataMsg{nil, err}
+		costData, report, err := m.registry.FetchCostData(m.ctx)
+		if err == nil && !costData.Stale && m.costCache != nil {
+			_ = m.costCache.Save(m.ctx, domain.CachedCost{SavedAt: time.Now(), Source: report.Source, Cost: costData})
+		}
+		if err != nil || m.converter == nil {
+			return costDataMsg{costData, report, err}
 		}
 
-		costData, err := dataSourcePlugin.FetchCostData(m.ctx)
-		return costDataMsg{costData, err}
+		convertedData, err := m.converter.Convert(m.ctx, costData)
+		return costDataMsg{convertedData, report, err}
 	}
 }
 
+// loadCachedCost loads the cost data kept by the previous run, marked as stale once it
+// is older than the data source cache time. Nothing is shown when there is none.
+func (m *Model) loadCachedCost() tea.Cmd {
+	if m.costCache == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		cached, err := m.costCache.Load(m.ctx)
+		if err != nil || cached == nil {
+			return nil
+		}
+
+		costData := *cached.Cost
+		costData.Stale = cached.IsStale(time.Now(), m.config.GetConfig().DataSource.CacheTime)
+		converted := &costData
+		if m.converter != nil {
+			if converted, err = m.converter.Convert(m.ctx, converted); err != nil {
+				return nil
+			}
+		}
+
+		return cachedCostMsg{converted, cached.Source, cached.SavedAt}
+	}
+}
+
+// formatUpdateTime formats when data was fetched, with the date unless it was today
+func formatUpdateTime(updated, now time.Time) string {
+	if updated.Format("2006-01-02") == now.Format("2006-01-02") {
+		return updated.Format("15:04")
+	}
+	return updated.Format("Jan 2 15:04")
+}
+
 // tick creates a tick command for animation
 func (m *Model) tick() tea.Cmd {
+	return tea.Tick(m.frameInterval(), func(t time.Time) tea.Msg {
+		return tickMsg{t}
+	})
+}
+
+// frameInterval returns the time between animation frames, one second when animation is off
+func (m *Model) frameInterval() time.Duration {
 	animationConfig := m.config.GetAnimationConfig()
 	if animationConfig == nil || !animationConfig.Enabled {
-		return tea.Tick(1*time.Second, func(time.Time) tea.Msg {
-			return tickMsg{}
-		})
+		return 1 * time.Second
 	}
 
-	return tea.Tick(animationConfig.Speed, func(time.Time) tea.Msg {
-		return tickMsg{}
-	})
-}
-
-// formatFloat formats a float64 to a string with 2 decimal places
-func formatFloat(f float64) string {
-	return strconv.FormatFloat(f, 'f', 2, 64)
+	return animationConfig.Speed
 }
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleMouse reacts to clicks, hovering and the scroll wheel
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	m.mouseX, m.mouseY = msg.X, msg.Y
+	m.hovering = true
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.changeSparkWindow(-sparkWindowStep)
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.changeSparkWindow(sparkWindowStep)
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		layout, ok := m.currentLayout()
+		if !ok {
+			return
+		}
+		region := layout.hovered(m)
+		if region == nil {
+			return
+		}
+		switch region.kind {
+		case regionTotal:
+			m.cyclePeriod(1)
+		case regionModel:
+			if region.index < len(layout.panel.models) {
+				m.toggleModel(layout.panel.models[region.index].name)
+			}
+		}
+	}
+}
+
+// changeSparkWindow widens or narrows the number of days in the sparkline
+func (m *Model) changeSparkWindow(step int) {
+	m.sparkWindow = max(minSparkWindow, min(maxSparkWindow, m.sparkWindow+step))
+}
+
+// cyclePeriod switches to the next report period. Periods other than "all"
+// need a daily series and are skipped without one.
+func (m *Model) cyclePeriod(step int) {
+	if m.currentCost == nil || len(m.currentCost.Daily) == 0 {
+		return
+	}
+
+	periods := domain.ReportPeriods()
+	current := max(0, slices.Index(periods, m.period))
+	m.changeView(func() {
+		m.period = periods[wrapIndex(current+step, len(periods))]
+	})
+}
+
+// toggleModel drills into a model, or back out when it is already selected
+func (m *Model) toggleModel(name string) {
+	m.changeView(func() {
+		if m.selectedModel == name {
+			m.selectedModel = ""
+		} else {
+			m.selectedModel = name
+		}
+	})
+}
+
+// changeView applies a change of period or model, counting up to the new total
+func (m *Model) changeView(change func()) {
+	now := m.clock()
+	previous := m.displayedCost()
+	change()
+	m.startTransition(previous, m.reportCost(m.currentCost), now, false)
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// placeOverlay draws foreground centered on top of background, keeping the
+// background visible around it. Both may contain ANSI escape sequences.
+func placeOverlay(background, foreground string, width, height int) string {
+	bgLines := strings.Split(background, "\n")
+	fgLines := strings.Split(foreground, "\n")
+	fgWidth := lipgloss.Width(foreground)
+
+	for len(bgLines) < height {
+		bgLines = append(bgLines, "")
+	}
+
+	x := max(0, (width-fgWidth)/2)
+	y := max(0, (len(bgLines)-len(fgLines))/2)
+
+	for i, fgLine := range fgLines {
+		row := y + i
+		if row >= len(bgLines) {
+			break
+		}
+		bgLine := bgLines[row]
+
+		left := ansi.Truncate(bgLine, x, "")
+		if gap := x - ansi.StringWidth(left); gap > 0 {
+			left += strings.Repeat(" ", gap)
+		}
+		right := ansi.TruncateLeft(bgLine, x+ansi.StringWidth(fgLine), "")
+
+		var line strings.Builder
+		line.WriteString(left)
+		if strings.Contains(left, "\x1b[") {
+			// Clear styling left open by the background before the overlay is drawn
+			line.WriteString(terminal.ResetSequence)
+		}
+		line.WriteString(fgLine)
+		// TruncateLeft keeps the escape sequences it skips, restoring the background style
+		line.WriteString(right)
+		bgLines[row] = line.String()
+	}
+
+	return strings.Join(bgLines, "\n")
+}
+
+// overlayCell is a single styled character drawn over the background
+type overlayCell struct {
+	x, y int
+	text string
+}
+
+// placeCells draws single-width cells on top of background at their positions.
+// Cells outside the given size are dropped and later cells win on the same position.
+func placeCells(background string, cells []overlayCell, width, height int) string {
+	rows := make(map[int]map[int]string)
+	for _, cell := range cells {
+		if cell.x < 0 || cell.x >= width || cell.y < 0 || cell.y >= height {
+			continue
+		}
+		if rows[cell.y] == nil {
+			rows[cell.y] = make(map[int]string)
+		}
+		rows[cell.y][cell.x] = cell.text
+	}
+	if len(rows) == 0 {
+		return background
+	}
+
+	bgLines := strings.Split(background, "\n")
+	for len(bgLines) < height {
+		bgLines = append(bgLines, "")
+	}
+
+	for row, rowCells := range rows {
+		columns := make([]int, 0, len(rowCells))
+		for column := range rowCells {
+			columns = append(columns, column)
+		}
+		sort.Ints(columns)
+
+		bgLine := bgLines[row]
+		var line strings.Builder
+		position := 0
+		for _, column := range columns {
+			segment := ansi.Cut(bgLine, position, column)
+			line.WriteString(segment)
+			if gap := column - position - ansi.StringWidth(segment); gap > 0 {
+				line.WriteString(strings.Repeat(" ", gap))
+			}
+			if strings.Contains(segment, "\x1b[") {
+				line.WriteString(terminal.ResetSequence)
+			}
+			line.WriteString(rowCells[column])
+			position = column + 1
+		}
+		line.WriteString(ansi.TruncateLeft(bgLine, position, ""))
+		bgLines[row] = line.String()
+	}
+
+	return strings.Join(bgLines, "\n")
+}
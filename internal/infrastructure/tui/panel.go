@@ -0,0 +1,319 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Details panel layout, in terminal cells
+const (
+	panelRows       = 4 // Model rows and sparkline height
+	panelHeight     = panelRows + 2
+	panelGap        = 4
+	modelNameWidth  = 18
+	modelCostWidth  = 10
+	modelBarWidth   = 5
+	breakdownWidth  = 2 + modelNameWidth + 1 + modelCostWidth + 1 + modelBarWidth
+	sparkTitleWidth = 11 // Widest sparkline title, "Daily · 90d"
+	minPanelWidth   = breakdownWidth + panelGap + sparkTitleWidth
+	minPanelHeight  = 20 // Terminal height below which the panel is hidden
+	minSparkWindow  = 7
+	maxSparkWindow  = 90
+	sparkWindowStep = 7
+)
+
+// regionKind identifies what a clickable region of the screen shows
+type regionKind int
+
+const (
+	regionTotal regionKind = iota
+	regionModel
+	regionDay
+)
+
+// hitRegion is a rectangle of the screen that reacts to the mouse
+type hitRegion struct {
+	x, y, width, height int
+	kind                regionKind
+	index               int
+}
+
+// contains reports whether the cell lies within the region
+func (r hitRegion) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+// modelCost is a model with its cost in the report period
+type modelCost struct {
+	name string
+	cost float64
+}
+
+// panelLayout is the content and position of the details panel for the current frame
+type panelLayout struct {
+	period    domain.ReportPeriod
+	total     float64
+	models    []modelCost
+	days      []domain.DailyCost
+	top, left int
+}
+
+// sparkLeft returns the screen column where the sparkline starts
+func (p *panelLayout) sparkLeft() int {
+	return p.left + breakdownWidth + panelGap
+}
+
+// screenLayout is where the display and the details panel go on the screen and what
+// reacts to the mouse. It is worked out from the model without changing it, so that
+// View and the mouse handling agree.
+type screenLayout struct {
+	footer        string
+	displayHeight int
+	panel         *panelLayout // Nil when the panel is hidden
+	regions       []hitRegion
+}
+
+// hovered returns the region under the mouse pointer, if any
+func (l screenLayout) hovered(m *Model) *hitRegion {
+	if !m.hovering {
+		return nil
+	}
+	for i := len(l.regions) - 1; i >= 0; i-- {
+		if l.regions[i].contains(m.mouseX, m.mouseY) {
+			return &l.regions[i]
+		}
+	}
+	return nil
+}
+
+// clock returns the time of the current frame
+func (m *Model) clock() time.Time {
+	if m.now.IsZero() {
+		return time.Now()
+	}
+	return m.now
+}
+
+// reportCost returns the cost data for the selected report period and model
+func (m *Model) reportCost(data *domain.CostData) *domain.CostData {
+	return domain.FilterCostData(data, m.period, m.selectedModel, m.clock())
+}
+
+// showPanel reports whether the details panel fits and has something to show.
+// Displays that render their own breakdown do not get the panel.
+func (m *Model) showPanel(displayPlugin interfaces.DisplayPlugin) bool {
+	if !m.showDetails || displayPlugin.GetCapabilities().ShowsBreakdown || m.currentCost == nil || m.width < minPanelWidth || m.height < minPanelHeight {
+		return false
+	}
+	return len(m.currentCost.ModelBreakdown) > 0 || len(m.currentCost.Daily) > 0
+}
+
+// layoutScreen works out the height left for the display, the details panel below it
+// and the regions that react to the mouse
+func (m *Model) layoutScreen(displayPlugin interfaces.DisplayPlugin, displayConfig *domain.DisplayConfig) screenLayout {
+	layout := screenLayout{footer: m.renderFooter(displayConfig), displayHeight: m.height}
+	if layout.footer != "" {
+		layout.displayHeight -= lipgloss.Height(layout.footer)
+	}
+	if m.showPanel(displayPlugin) {
+		layout.displayHeight -= panelHeight
+		panel := m.layoutPanel(layout.displayHeight)
+		layout.panel = &panel
+	}
+
+	// The total reacts to the mouse across the whole display area
+	layout.regions = []hitRegion{{width: m.width, height: layout.displayHeight, kind: regionTotal}}
+	if panel := layout.panel; panel != nil {
+		for i := range panel.models {
+			layout.regions = append(layout.regions, hitRegion{x: panel.left, y: panel.top + 1 + i, width: breakdownWidth, height: 1, kind: regionModel, index: i})
+		}
+		layout.regions = append(layout.regions, hitRegion{x: panel.sparkLeft(), y: panel.top + 1, width: len(panel.days), height: panelRows, kind: regionDay})
+	}
+	return layout
+}
+
+// currentLayout lays out the screen as View would show it, or reports false
+// when there is no cost data on the screen
+func (m *Model) currentLayout() (screenLayout, bool) {
+	if m.isLoading || m.error != nil || m.currentCost == nil {
+		return screenLayout{}, false
+	}
+
+	displayPlugin, err := m.registry.GetActiveDisplay()
+	if err != nil {
+		return screenLayout{}, false
+	}
+	displayConfig := m.config.GetDisplayConfig()
+	if displayConfig == nil {
+		return screenLayout{}, false
+	}
+	return m.layoutScreen(displayPlugin, displayConfig), true
+}
+
+// sparkTitle titles a sparkline of the given number of days
+func sparkTitle(days int) string {
+	return fmt.Sprintf("Daily · %dd", days)
+}
+
+// layoutPanel collects the data shown in the details panel at the given screen row
+func (m *Model) layoutPanel(top int) panelLayout {
+	now := m.clock()
+	periodData := domain.FilterCostData(m.currentCost, m.period, "", now)
+
+	models := make([]modelCost, 0, len(periodData.ModelBreakdown))
+	for name, cost := range periodData.ModelBreakdown {
+		models = append(models, modelCost{name, cost})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].cost != models[j].cost {
+			return models[i].cost > models[j].cost
+		}
+		return models[i].name < models[j].name
+	})
+	if len(models) > panelRows {
+		models = models[:panelRows]
+	}
+
+	// Daily series over the sparkline window, ending today
+	seriesData := domain.FilterCostData(m.currentCost, domain.PeriodAll, m.selectedModel, now)
+	window := min(m.sparkWindow, m.width-breakdownWidth-panelGap)
+	days := domain.DailySeries(seriesData.Daily, now, window)
+
+	panelWidth := breakdownWidth + panelGap + max(len(days), ansi.StringWidth(sparkTitle(len(days))))
+	return panelLayout{
+		period: m.period,
+		total:  periodData.TotalCost,
+		models: models,
+		days:   days,
+		top:    top,
+		left:   max(0, (m.width-panelWidth)/2),
+	}
+}
+
+// renderPanel renders the details panel, highlighting the row under the mouse pointer
+func (m *Model) renderPanel(screen screenLayout, profile domain.ColorProfile, format domain.NumberFormat) string {
+	layout := screen.panel
+	renderer := terminal.NewRenderer(profile)
+	titleStyle := renderer.NewStyle().Faint(true)
+	selectedStyle := renderer.NewStyle().Bold(true)
+	hoverStyle := renderer.NewStyle().Underline(true)
+	barStyle := renderer.NewStyle().Foreground(terminal.AdaptColor("#5FAFFF", profile))
+
+	hover := screen.hovered(m)
+
+	// Breakdown rows
+	title := "Breakdown · " + string(layout.period)
+	if m.selectedModel != "" {
+		title += " · " + m.selectedModel
+	}
+	breakdown := []string{titleStyle.Render(ansi.Truncate(title, breakdownWidth, "…"))}
+	for i, model := range layout.models {
+		marker := "  "
+		if model.name == m.selectedModel {
+			marker = "▸ "
+		}
+		share := 0.0
+		if layout.total > 0 {
+			share = model.cost / layout.total
+		}
+		row := ansi.Truncate(fmt.Sprintf("%s%-*s %*s %s",
+			marker,
+			modelNameWidth, ansi.Truncate(model.name, modelNameWidth, "…"),
+			modelCostWidth, domain.FormatCost(model.cost, m.currentCost.Currency, format),
+			shareBar(share, modelBarWidth),
+		), breakdownWidth, "")
+
+		switch {
+		case model.name == m.selectedModel:
+			row = selectedStyle.Render(row)
+		case hover != nil && hover.kind == regionModel && hover.index == i:
+			row = hoverStyle.Render(row)
+		}
+		breakdown = append(breakdown, row)
+	}
+
+	// Sparkline
+	sparkline := []string{titleStyle.Render(sparkTitle(len(layout.days)))}
+	for _, row := range terminal.BarRows(layout.days, panelRows, 0) {
+		sparkline = append(sparkline, barStyle.Render(row))
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderer.NewStyle().Width(breakdownWidth).Render(strings.Join(breakdown, "\n")),
+		strings.Repeat(" ", panelGap),
+		strings.Join(sparkline, "\n"),
+	)
+	body = renderer.NewStyle().Height(panelRows + 1).Render(body)
+	body = lipgloss.PlaceHorizontal(m.width, lipgloss.Left, indent(body, layout.left))
+
+	tooltip := titleStyle.Render(m.tooltip(screen, format))
+	return body + "\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, tooltip)
+}
+
+// tooltip describes the exact values under the mouse pointer
+func (m *Model) tooltip(screen screenLayout, format domain.NumberFormat) string {
+	region := screen.hovered(m)
+	if region == nil || screen.panel == nil {
+		return ""
+	}
+
+	exact := format
+	exact.Decimals = 4
+	exact.Abbreviate = false
+	currency := m.currentCost.Currency
+
+	switch region.kind {
+	case regionTotal:
+		data := m.reportCost(m.currentCost)
+		text := fmt.Sprintf("Total (%s): %s", m.period, domain.FormatCost(data.TotalCost, currency, exact))
+		if tokens := data.Tokens.Total(); tokens > 0 {
+			text += fmt.Sprintf(" · %s tokens", domain.FormatNumber(float64(tokens), 0, domain.DefaultNumberFormat()))
+		}
+		return text + " · click to change period"
+	case regionModel:
+		if region.index < len(screen.panel.models) {
+			model := screen.panel.models[region.index]
+			return fmt.Sprintf("%s: %s · click to drill in", model.name, domain.FormatCost(model.cost, currency, exact))
+		}
+	case regionDay:
+		index := m.mouseX - region.x
+		if index >= 0 && index < len(screen.panel.days) {
+			day := screen.panel.days[index]
+			return fmt.Sprintf("%s: %s", day.Date.Format("2006-01-02"), domain.FormatCost(day.Cost, currency, exact))
+		}
+	}
+	return ""
+}
+
+// shareBar draws a horizontal bar filled in proportion to share
+func shareBar(share float64, width int) string {
+	filled := int(share*float64(width) + 0.5)
+	filled = max(0, min(width, filled))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// indent prefixes every line of text with spaces
+func indent(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	padding := strings.Repeat(" ", width)
+	return padding + strings.ReplaceAll(text, "\n", "\n"+padding)
+}
+
+// fitHeight pads or cuts text to exactly the given number of lines
+func fitHeight(text string, height int) string {
+	lines := strings.Split(text, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:max(0, height)], "\n")
+}
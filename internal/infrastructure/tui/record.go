@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Record renders the model without a terminal on a fixed canvas for the given duration.
+// Time advances by one animation frame at a time rather than in real time, and each
+// frame is passed to the callback with its offset from the start.
+func (m *Model) Record(duration time.Duration, width, height int, frame func(offset time.Duration, view string) error) error {
+	m.showHint = false
+	m.muted = true
+	m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+	msg := m.fetchCostData()().(costDataMsg)
+	if msg.err != nil {
+		return msg.err
+	}
+	m.Update(msg)
+
+	start := time.Now()
+	interval := m.frameInterval()
+	for offset := time.Duration(0); offset <= duration; offset += interval {
+		if offset > 0 {
+			m.Update(tickMsg{start.Add(offset)})
+		}
+		if err := frame(offset, m.View()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Timing of the changed-digit flash
+const (
+	flashHold     = 1 * time.Second // How long digits keep flashing after the count-up ends
+	flashInterval = 200 * time.Millisecond
+)
+
+// costTransition tracks the count-up from a previous cost to a new one
+type costTransition struct {
+	from      float64
+	to        float64
+	startedAt time.Time
+	config    domain.TransitionConfig
+}
+
+// startTransition begins a count-up from the cost currently shown to the new cost.
+// The delta badge is only shown when badge is set, i.e. for actual cost changes.
+func (m *Model) startTransition(previous, next *domain.CostData, now time.Time, badge bool) {
+	if previous == nil || next == nil || previous.Currency != next.Currency || previous.TotalCost == next.TotalCost {
+		return
+	}
+
+	from := previous.TotalCost
+	if m.transition != nil {
+		// Continue from the value on screen when a transition is still running
+		from = m.transition.value(now)
+	}
+
+	config := m.config.GetTransitionConfig()
+	config.DeltaBadge = config.DeltaBadge && badge
+	m.transition = &costTransition{
+		from:      from,
+		to:        next.TotalCost,
+		startedAt: now,
+		config:    config,
+	}
+}
+
+// updateTransition advances the clock and drops the transition once all of its effects ended
+func (m *Model) updateTransition(now time.Time) {
+	m.now = now
+	if m.transition != nil && m.transition.done(now) {
+		m.transition = nil
+	}
+}
+
+// value returns the cost to show at the given time
+func (t *costTransition) value(now time.Time) float64 {
+	if t.config.Duration <= 0 {
+		return t.to
+	}
+
+	progress := float64(now.Sub(t.startedAt)) / float64(t.config.Duration)
+	return domain.Interpolate(t.from, t.to, domain.Ease(t.config.Easing, progress))
+}
+
+// flashing reports whether changed digits are highlighted at the given time
+func (t *costTransition) flashing(now time.Time) bool {
+	elapsed := now.Sub(t.startedAt)
+	if !t.config.FlashDigits || elapsed >= t.config.Duration+flashHold {
+		return false
+	}
+	return int(elapsed/flashInterval)%2 == 0
+}
+
+// badgeVisible reports whether the delta badge is shown at the given time
+func (t *costTransition) badgeVisible(now time.Time) bool {
+	return t.config.DeltaBadge && now.Sub(t.startedAt) < t.config.Duration+t.config.BadgeDuration
+}
+
+// done reports whether every effect of the transition has ended
+func (t *costTransition) done(now time.Time) bool {
+	elapsed := now.Sub(t.startedAt)
+	return elapsed >= t.config.Duration+flashHold && !t.badgeVisible(now)
+}
+
+// displayedCost returns the cost data to render for the selected period and model,
+// with the total tweened during a transition
+func (m *Model) displayedCost() *domain.CostData {
+	reported := m.reportCost(m.currentCost)
+	if m.transition == nil {
+		return reported
+	}
+
+	displayed := *reported
+	displayed.TotalCost = m.transition.value(m.now)
+	return &displayed
+}
+
+// highlightedChars returns the characters of the displayed cost text to flash, if any
+func (m *Model) highlightedChars(costText string, format domain.NumberFormat) []bool {
+	if m.transition == nil || !m.transition.flashing(m.now) {
+		return nil
+	}
+
+	fromText := domain.FormatCost(m.transition.from, m.currentCost.Currency, format)
+	return domain.ChangedChars(fromText, costText)
+}
+
+// renderDeltaBadge renders the transient "+$2.67" badge, or an empty string when hidden
+func (m *Model) renderDeltaBadge(profile domain.ColorProfile, format domain.NumberFormat) string {
+	if m.transition == nil || !m.transition.badgeVisible(m.now) {
+		return ""
+	}
+
+	delta := m.transition.to - m.transition.from
+	text := domain.FormatCost(delta, m.currentCost.Currency, format)
+	color := "#FF5F5F"
+	if delta > 0 {
+		text = "+" + text
+	} else {
+		color = "#5FFF87"
+	}
+
+	badgeStyle := terminal.NewRenderer(profile).NewStyle().Bold(true).Foreground(terminal.AdaptColor(color, profile))
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, badgeStyle.Render(text))
+}
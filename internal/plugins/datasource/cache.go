@@ -0,0 +1,128 @@
+package datasource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// CostLoader loads fresh cost data for a cache
+type CostLoader func(ctx context.Context) (*domain.CostData, error)
+
+// CostCache keeps the latest cost data of a data source. Concurrent loads are
+// coalesced into one, and stale data is served while it is refreshed in the background.
+type CostCache struct {
+	ttl  time.Duration
+	load CostLoader
+
+	mu         sync.Mutex
+	data       *domain.CostData
+	updated    time.Time
+	refreshErr error      // Failure of the latest load, the next caller waits for a retry
+	inflight   *cacheLoad // Load in progress, shared by every caller
+	generation int        // Changed by Clear so that loads in progress are not stored
+}
+
+// cacheLoad is a load shared by the callers that wait for it
+type cacheLoad struct {
+	done chan struct{}
+	data *domain.CostData
+	err  error
+}
+
+// NewCostCache creates a cache that considers data fresh for ttl and loads it with load
+func NewCostCache(ttl time.Duration, load CostLoader) *CostCache {
+	return &CostCache{ttl: ttl, load: load}
+}
+
+// SetTTL changes how long loaded data is considered fresh
+func (c *CostCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the cached data while it is fresh. Stale data is returned right away,
+// marked as stale, while a single background load refreshes it. Without data, or
+// after a failed refresh, the caller waits for the load, joining one already in progress.
+func (c *CostCache) Get(ctx context.Context) (*domain.CostData, error) {
+	c.mu.Lock()
+	if c.data != nil && time.Since(c.updated) < c.ttl {
+		data := c.data
+		c.mu.Unlock()
+		return data, nil
+	}
+
+	if c.data != nil && c.refreshErr == nil {
+		stale := *c.data
+		stale.Stale = true
+		c.startLoad(ctx)
+		c.mu.Unlock()
+		return &stale, nil
+	}
+
+	load := c.startLoad(ctx)
+	c.mu.Unlock()
+
+	select {
+	case <-load.done:
+		return load.data, load.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// startLoad starts a load unless one is in progress and returns it. The load outlives
+// the caller that started it, since other callers may be waiting for it. The caller holds the lock.
+func (c *CostCache) startLoad(ctx context.Context) *cacheLoad {
+	if c.inflight != nil {
+		return c.inflight
+	}
+
+	load := &cacheLoad{done: make(chan struct{})}
+	c.inflight = load
+	generation := c.generation
+
+	go func() {
+		data, err := c.load(context.WithoutCancel(ctx))
+
+		c.mu.Lock()
+		if generation == c.generation {
+			if err == nil {
+				c.data = data
+				c.updated = time.Now()
+			}
+			c.refreshErr = err
+		}
+		if c.inflight == load {
+			c.inflight = nil
+		}
+		c.mu.Unlock()
+
+		load.data, load.err = data, err
+		close(load.done)
+	}()
+
+	return load
+}
+
+// Updated returns when the cached data was loaded, zero when there is none
+func (c *CostCache) Updated() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.updated
+}
+
+// Clear drops the cached data. A load in progress still completes for its callers,
+// but its data is not kept.
+func (c *CostCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.inflight = nil
+	c.data = nil
+	c.updated = time.Time{}
+	c.refreshErr = nil
+}
@@ -3,24 +3,34 @@ package datasource
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os/exec"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
 )
 
+// stderrLines is how many lines of ccusage's error output are kept in errors
+const stderrLines = 3
+
 // CcusageCliPlugin implements the DataSourcePlugin interface for ccusage CLI
 type CcusageCliPlugin struct {
 	name        string
 	version     string
 	description string
+	cache       *CostCache
+
+	mu          sync.Mutex // Guards the settings, as health checks may initialize the plugin during a fetch
 	enabled     bool
 	ccusagePath string
+	configDir   string // Claude data directory to read instead of the default, for other accounts
 	timeout     time.Duration
 	cacheTime   time.Duration
-	lastUpdate  time.Time
-	cachedData  *domain.CostData
+	runner      CommandRunner
 }
 
 // CcusageResponse represents the JSON response from ccusage CLI
@@ -31,8 +41,12 @@ type CcusageResponse struct {
 
 // DailyEntry represents a single day's usage data
 type DailyEntry struct {
-	Date string  `json:"date"`
-	Cost float64 `json:"cost"`
+	Date            string           `json:"date"`
+	Cost            float64          `json:"cost"`
+	TotalCost       float64          `json:"totalCost"` // Reported instead of cost by recent ccusage versions
+	InputTokens     int              `json:"inputTokens"`
+	OutputTokens    int              `json:"outputTokens"`
+	ModelBreakdowns []ModelBreakdown `json:"modelBreakdowns"`
 }
 
 // TotalsData represents the totals section of ccusage output
@@ -46,6 +60,7 @@ type TotalsData struct {
 // ModelBreakdown represents per-model cost breakdown
 type ModelBreakdown struct {
 	Model        string  `json:"model"`
+	ModelName    string  `json:"modelName"` // Reported instead of model by recent ccusage versions
 	InputTokens  int     `json:"inputTokens"`
 	OutputTokens int     `json:"outputTokens"`
 	Cost         float64 `json:"cost"`
@@ -53,7 +68,7 @@ type ModelBreakdown struct {
 
 // NewCcusageCliPlugin creates a new ccusage CLI plugin
 func NewCcusageCliPlugin() *CcusageCliPlugin {
-	return &CcusageCliPlugin{
+	plugin := &CcusageCliPlugin{
 		name:        "ccusage-cli",
 		version:     "1.0.0",
 		description: "ccusage CLI data source plugin",
@@ -61,7 +76,17 @@ func NewCcusageCliPlugin() *CcusageCliPlugin {
 		ccusagePath: "ccusage",
 		timeout:     30 * time.Second,
 		cacheTime:   10 * time.Second,
+		runner:      execRunner{},
 	}
+	plugin.cache = NewCostCache(plugin.cacheTime, plugin.fetch)
+	return plugin
+}
+
+// SetCommandRunner sets how the ccusage command is run, as a child process by default
+func (c *CcusageCliPlugin) SetCommandRunner(runner CommandRunner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runner = runner
 }
 
 // Name returns the plugin name
@@ -81,15 +106,24 @@ func (c *CcusageCliPlugin) Description() string {
 
 // IsEnabled returns whether the plugin is enabled
 func (c *CcusageCliPlugin) IsEnabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.enabled
 }
 
 // Initialize initializes the plugin with configuration
 func (c *CcusageCliPlugin) Initialize(config map[string]interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if ccusagePath, ok := config["ccusage_path"].(string); ok {
 		c.ccusagePath = ccusagePath
 	}
 
+	if configDir, ok := config["config_dir"].(string); ok {
+		c.configDir = configDir
+	}
+
 	if timeout, ok := config["timeout"].(string); ok {
 		if duration, err := time.ParseDuration(timeout); err == nil {
 			c.timeout = duration
@@ -101,6 +135,7 @@ func (c *CcusageCliPlugin) Initialize(config map[string]interface{}) error {
 			c.cacheTime = duration
 		}
 	}
+	c.cache.SetTTL(c.cacheTime)
 
 	c.enabled = true
 	return nil
@@ -108,44 +143,83 @@ func (c *CcusageCliPlugin) Initialize(config map[string]interface{}) error {
 
 // Shutdown shuts down the plugin
 func (c *CcusageCliPlugin) Shutdown() error {
+	c.mu.Lock()
 	c.enabled = false
-	c.cachedData = nil
+	c.mu.Unlock()
+	c.cache.Clear()
 	return nil
 }
 
-// FetchCostData fetches cost data from ccusage CLI
+// HealthCheck reports whether the command used to run ccusage can be found
+func (c *CcusageCliPlugin) HealthCheck(ctx context.Context) error {
+	if !c.IsEnabled() {
+		return domain.ErrPluginNotEnabled
+	}
+
+	c.mu.Lock()
+	command := c.ccusagePath
+	c.mu.Unlock()
+	if command == "ccusage" {
+		command = "npx"
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		return &domain.DataSourceError{
+			Class: runErrorClass(Command{Name: command}, err),
+			Err:   fmt.Errorf("ccusage command not available: %w", err),
+		}
+	}
+	return nil
+}
+
+// FetchCostData fetches cost data from ccusage CLI. Recent data is served from the
+// cache, and concurrent fetches share a single ccusage run.
 func (c *CcusageCliPlugin) FetchCostData(ctx context.Context) (*domain.CostData, error) {
-	if !c.enabled {
+	if !c.IsEnabled() {
 		return nil, domain.ErrPluginNotEnabled
 	}
 
-	// Check cache first
-	if c.cachedData != nil && time.Since(c.lastUpdate) < c.cacheTime {
-		return c.cachedData, nil
-	}
+	return c.cache.Get(ctx)
+}
+
+// fetch runs ccusage and converts its report
+func (c *CcusageCliPlugin) fetch(ctx context.Context) (*domain.CostData, error) {
+	c.mu.Lock()
+	ccusagePath, configDir, timeout, runner := c.ccusagePath, c.configDir, c.timeout, c.runner
+	c.mu.Unlock()
 
 	// Create context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Execute ccusage command with JSON output via npx
-	var cmd *exec.Cmd
-	if c.ccusagePath == "ccusage" {
+	command := Command{Name: ccusagePath, Args: []string{"daily", "--json"}}
+	if ccusagePath == "ccusage" {
 		// Use npx for default ccusage command
-		cmd = exec.CommandContext(timeoutCtx, "npx", "ccusage", "daily", "--json")
-	} else {
-		// Use custom path as specified
-		cmd = exec.CommandContext(timeoutCtx, c.ccusagePath, "daily", "--json")
+		command = Command{Name: "npx", Args: []string{"ccusage", "daily", "--json"}}
+	}
+	if configDir != "" {
+		command.Env = []string{"CLAUDE_CONFIG_DIR=" + configDir}
+	}
+	result, err := runner.Run(timeoutCtx, command)
+	if err := timeoutCtx.Err(); err != nil {
+		return nil, contextError(ctx, timeout, result, err)
 	}
-	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute ccusage command: %w", err)
+		return nil, commandError(runErrorClass(command, err), result, fmt.Errorf("failed to execute ccusage command: %w", err))
+	}
+	if result.ExitCode != 0 {
+		err := fmt.Errorf("failed to execute ccusage command: exit status %d", result.ExitCode)
+		if stderr := lastLines(result.Stderr, stderrLines); stderr != "" {
+			err = fmt.Errorf("failed to execute ccusage command: exit status %d: %s", result.ExitCode, stderr)
+		}
+		return nil, commandError(exitClass(result), result, err)
 	}
 
 	// Parse JSON response
 	var response CcusageResponse
-	if err := json.Unmarshal(output, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse ccusage JSON output: %w (raw output: %s)", err, string(output))
+	if err := json.Unmarshal(result.Stdout, &response); err != nil {
+		return nil, commandError(domain.ErrParseFailure, result,
+			fmt.Errorf("failed to parse ccusage JSON output: %w (raw output: %s)", err, string(result.Stdout)))
 	}
 
 	// Parse date from the most recent daily entry or use current time
@@ -162,10 +236,14 @@ func (c *CcusageCliPlugin) FetchCostData(ctx context.Context) (*domain.CostData,
 		timestamp = time.Now()
 	}
 
-	// Build model breakdown from model breakdowns
-	modelBreakdown := make(map[string]float64)
-	for _, breakdown := range response.Totals.ModelBreakdowns {
-		modelBreakdown[breakdown.Model] = breakdown.Cost
+	// ccusage only reports model breakdowns per day, so sum them when the totals lack one
+	modelBreakdown := buildModelBreakdown(response.Totals.ModelBreakdowns)
+	if len(modelBreakdown) == 0 {
+		for _, entry := range response.Daily {
+			for model, cost := range buildModelBreakdown(entry.ModelBreakdowns) {
+				modelBreakdown[model] += cost
+			}
+		}
 	}
 
 	// Convert to domain model
@@ -174,22 +252,124 @@ func (c *CcusageCliPlugin) FetchCostData(ctx context.Context) (*domain.CostData,
 		Currency:       "USD", // ccusage typically uses USD
 		Timestamp:      timestamp,
 		ModelBreakdown: modelBreakdown,
+		Tokens: domain.TokenUsage{
+			Input:  response.Totals.InputTokens,
+			Output: response.Totals.OutputTokens,
+		},
+		Daily: buildDailyCosts(response.Daily),
 	}
 
-	// Update cache
-	c.cachedData = costData
-	c.lastUpdate = time.Now()
-
 	return costData, nil
 }
 
+// commandError classifies a failed ccusage run, keeping its error output
+func commandError(class error, result CommandResult, err error) error {
+	return &domain.DataSourceError{Class: class, Stderr: strings.TrimSpace(string(result.Stderr)), Err: err}
+}
+
+// contextError tells whether ccusage ran into its own timeout, the deadline of
+// the caller or a cancellation, as the context of the run only reports which one ended it
+func contextError(ctx context.Context, timeout time.Duration, result CommandResult, err error) error {
+	switch {
+	case ctx.Err() == nil:
+		return commandError(domain.ErrTimeout, result, fmt.Errorf("failed to execute ccusage command: timed out after %s: %w", timeout, err))
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return commandError(domain.ErrTimeout, result, fmt.Errorf("failed to execute ccusage command: caller deadline exceeded: %w", ctx.Err()))
+	default:
+		return fmt.Errorf("failed to execute ccusage command: %w", ctx.Err())
+	}
+}
+
+// runErrorClass classifies a command that could not be run. Without npx, Node.js is missing.
+func runErrorClass(command Command, err error) error {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return domain.ErrPermissionDenied
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		if command.Name == "npx" {
+			return domain.ErrNodeMissing
+		}
+		return domain.ErrBinaryNotFound
+	default:
+		return domain.ErrCommandFailed
+	}
+}
+
+// exitClass classifies a command that exited with an error by what it wrote to stderr
+func exitClass(result CommandResult) error {
+	stderr := strings.ToLower(string(result.Stderr))
+	switch {
+	case strings.Contains(stderr, "eacces"), strings.Contains(stderr, "permission denied"):
+		return domain.ErrPermissionDenied
+	case strings.Contains(stderr, "env: node"), strings.Contains(stderr, "env: 'node'"), strings.Contains(stderr, "node: not found"):
+		return domain.ErrNodeMissing
+	case result.ExitCode == 127, strings.Contains(stderr, "command not found"), strings.Contains(stderr, "could not determine executable"):
+		return domain.ErrBinaryNotFound
+	case strings.Contains(stderr, "no valid claude data director"), strings.Contains(stderr, "no usage data"):
+		return domain.ErrNoUsageData
+	default:
+		return domain.ErrCommandFailed
+	}
+}
+
+// lastLines returns the last non-empty lines of command output, joined with " | "
+func lastLines(output []byte, n int) string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines[max(len(lines)-n, 0):], " | ")
+}
+
+// buildModelBreakdown maps model names to their cost
+func buildModelBreakdown(breakdowns []ModelBreakdown) map[string]float64 {
+	modelBreakdown := make(map[string]float64)
+	for _, breakdown := range breakdowns {
+		model := breakdown.Model
+		if model == "" {
+			model = breakdown.ModelName
+		}
+		modelBreakdown[model] += breakdown.Cost
+	}
+	return modelBreakdown
+}
+
+// buildDailyCosts converts daily entries, skipping entries with unparseable dates
+func buildDailyCosts(entries []DailyEntry) []domain.DailyCost {
+	daily := make([]domain.DailyCost, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse("2006-01-02", entry.Date)
+		if err != nil {
+			continue
+		}
+
+		cost := entry.Cost
+		if cost == 0 {
+			cost = entry.TotalCost
+		}
+
+		daily = append(daily, domain.DailyCost{
+			Date: date,
+			Cost: cost,
+			Tokens: domain.TokenUsage{
+				Input:  entry.InputTokens,
+				Output: entry.OutputTokens,
+			},
+			ModelBreakdown: buildModelBreakdown(entry.ModelBreakdowns),
+		})
+	}
+	return daily
+}
+
 // GetLastUpdated returns the timestamp of the last data update
 func (c *CcusageCliPlugin) GetLastUpdated(ctx context.Context) (time.Time, error) {
-	if !c.enabled {
+	if !c.IsEnabled() {
 		return time.Time{}, domain.ErrPluginNotEnabled
 	}
 
-	return c.lastUpdate, nil
+	return c.cache.Updated(), nil
 }
 
 // SupportsRealtime returns whether the plugin supports real-time data
@@ -0,0 +1,173 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// CompositeMember is a data source whose cost is added to the composite total
+type CompositeMember struct {
+	Label  string
+	Source interfaces.DataSourcePlugin
+	Owned  bool // Created for the composite alone, which shuts it down
+}
+
+// CompositePlugin implements a data source that adds up the cost of several data sources
+type CompositePlugin struct {
+	name        string
+	version     string
+	description string
+	enabled     bool
+	members     []CompositeMember
+	timeout     time.Duration
+
+	mu         sync.Mutex
+	lastUpdate time.Time
+}
+
+// NewCompositePlugin creates a data source combining the members, each limited to the timeout
+func NewCompositePlugin(members []CompositeMember, timeout time.Duration) *CompositePlugin {
+	if timeout <= 0 {
+		timeout = domain.DefaultCompositeTimeout
+	}
+
+	return &CompositePlugin{
+		name:        "composite",
+		version:     "1.0.0",
+		description: "Adds up the cost of several data sources",
+		enabled:     false,
+		members:     members,
+		timeout:     timeout,
+	}
+}
+
+// Name returns the plugin name
+func (c *CompositePlugin) Name() string {
+	return c.name
+}
+
+// Version returns the plugin version
+func (c *CompositePlugin) Version() string {
+	return c.version
+}
+
+// Description returns the plugin description
+func (c *CompositePlugin) Description() string {
+	return c.description
+}
+
+// IsEnabled returns whether the plugin is enabled
+func (c *CompositePlugin) IsEnabled() bool {
+	return c.enabled
+}
+
+// Initialize initializes the plugin with configuration
+func (c *CompositePlugin) Initialize(config map[string]interface{}) error {
+	if len(c.members) == 0 {
+		return fmt.Errorf("composite data source has no sources")
+	}
+
+	c.enabled = true
+	return nil
+}
+
+// Shutdown shuts down the plugin and the members it owns. Other members are shut down by the registry.
+func (c *CompositePlugin) Shutdown() error {
+	c.enabled = false
+
+	var errs []error
+	for _, member := range c.members {
+		if !member.Owned {
+			continue
+		}
+		if err := member.Source.Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", member.Label, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FetchCostData fetches cost data from every member concurrently and adds it up.
+// Members that fail or time out are listed in the contributors without a cost;
+// an error is only returned when no member has data.
+func (c *CompositePlugin) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	if !c.enabled {
+		return nil, domain.ErrPluginNotEnabled
+	}
+
+	sources := make([]domain.SourceCost, len(c.members))
+	var wg sync.WaitGroup
+	for i, member := range c.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			memberCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			data, err := fetchWithin(memberCtx, member.Source)
+			sources[i] = domain.SourceCost{Label: member.Label, Data: data, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	combined := domain.CombineCostData(sources)
+	if combined == nil {
+		errs := make([]error, 0, len(sources))
+		for _, source := range sources {
+			if source.Err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", source.Label, source.Err))
+			}
+		}
+		return nil, fmt.Errorf("no composite source has cost data: %w", errors.Join(errs...))
+	}
+
+	c.mu.Lock()
+	c.lastUpdate = time.Now()
+	c.mu.Unlock()
+
+	return combined, nil
+}
+
+// fetchWithin fetches cost data from a source, giving up when the context is done
+// even if the source does not honor it
+func fetchWithin(ctx context.Context, source interfaces.DataSourcePlugin) (*domain.CostData, error) {
+	type result struct {
+		data *domain.CostData
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		data, err := source.FetchCostData(ctx)
+		done <- result{data, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.data, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %w", domain.ErrTimeout, ctx.Err())
+	}
+}
+
+// GetLastUpdated returns the timestamp of the last data update
+func (c *CompositePlugin) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	if !c.enabled {
+		return time.Time{}, domain.ErrPluginNotEnabled
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastUpdate, nil
+}
+
+// SupportsRealtime returns false as the members are polled
+func (c *CompositePlugin) SupportsRealtime() bool {
+	return false
+}
@@ -0,0 +1,57 @@
+package datasource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Command is an external command for a CommandRunner
+type Command struct {
+	Name string
+	Args []string
+	Env  []string // Added to the environment of the current process, as KEY=value
+}
+
+// CommandResult is what a command wrote and how it exited
+type CommandResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// CommandRunner runs external commands. A command that runs and exits with a
+// non-zero status is not an error; the exit code is in the result.
+type CommandRunner interface {
+	Run(ctx context.Context, command Command) (CommandResult, error)
+}
+
+// execRunner runs commands as child processes
+type execRunner struct{}
+
+// Run runs the command and waits for it to finish
+func (execRunner) Run(ctx context.Context, command Command) (CommandResult, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command.Name, command.Args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if len(command.Env) > 0 {
+		cmd.Env = append(os.Environ(), command.Env...)
+	}
+
+	err := cmd.Run()
+	result := CommandResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	if ctx.Err() != nil {
+		// The command was killed, so its exit status says nothing
+		return result, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	return result, err
+}
@@ -0,0 +1,121 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/snapshot"
+)
+
+// SharedDirPlugin implements a data source that adds up the team snapshots in a shared directory
+type SharedDirPlugin struct {
+	name        string
+	version     string
+	description string
+	enabled     bool
+	dir         *snapshot.Dir
+	maxAge      time.Duration
+
+	mu         sync.Mutex
+	lastUpdate time.Time
+}
+
+// NewSharedDirPlugin creates a data source reading the snapshots in dir, ignoring those older than maxAge
+func NewSharedDirPlugin(dir *snapshot.Dir, maxAge time.Duration) *SharedDirPlugin {
+	return &SharedDirPlugin{
+		name:        "shared-dir",
+		version:     "1.0.0",
+		description: "Adds up the cost shared by a team in a shared directory",
+		enabled:     false,
+		dir:         dir,
+		maxAge:      maxAge,
+	}
+}
+
+// Name returns the plugin name
+func (s *SharedDirPlugin) Name() string {
+	return s.name
+}
+
+// Version returns the plugin version
+func (s *SharedDirPlugin) Version() string {
+	return s.version
+}
+
+// Description returns the plugin description
+func (s *SharedDirPlugin) Description() string {
+	return s.description
+}
+
+// IsEnabled returns whether the plugin is enabled
+func (s *SharedDirPlugin) IsEnabled() bool {
+	return s.enabled
+}
+
+// Initialize initializes the plugin with configuration
+func (s *SharedDirPlugin) Initialize(config map[string]interface{}) error {
+	s.enabled = true
+	return nil
+}
+
+// Shutdown shuts down the plugin
+func (s *SharedDirPlugin) Shutdown() error {
+	s.enabled = false
+	return nil
+}
+
+// FetchCostData adds up the recent snapshots of every member, listing each member as a contributor.
+// Stale snapshots and files that cannot be read are left out.
+func (s *SharedDirPlugin) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	if !s.enabled {
+		return nil, domain.ErrPluginNotEnabled
+	}
+
+	snapshots, readErr := s.dir.Read()
+	now := time.Now()
+
+	var sources []domain.SourceCost
+	var latest time.Time
+	for _, snapshot := range snapshots {
+		if snapshot.IsStale(now, s.maxAge) {
+			continue
+		}
+		sources = append(sources, domain.SourceCost{Label: snapshot.Member, Data: snapshot.Cost})
+		if snapshot.WrittenAt.After(latest) {
+			latest = snapshot.WrittenAt
+		}
+	}
+
+	combined := domain.CombineCostData(sources)
+	if combined == nil {
+		if readErr != nil {
+			return nil, readErr
+		}
+		return nil, fmt.Errorf("no recent team snapshots in '%s': %w", s.dir.Path(), domain.ErrDataNotFound)
+	}
+
+	s.mu.Lock()
+	s.lastUpdate = latest
+	s.mu.Unlock()
+
+	return combined, nil
+}
+
+// GetLastUpdated returns when the most recent snapshot was written
+func (s *SharedDirPlugin) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	if !s.enabled {
+		return time.Time{}, domain.ErrPluginNotEnabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastUpdate, nil
+}
+
+// SupportsRealtime returns false as the directory is polled
+func (s *SharedDirPlugin) SupportsRealtime() bool {
+	return false
+}
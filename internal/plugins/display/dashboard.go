@@ -0,0 +1,333 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// paneSize is the size of a pane in terminal cells, borders included
+type paneSize struct {
+	width, height int
+}
+
+// minPaneSizes are the smallest sizes at which each pane is still useful.
+// Panes that do not get this much room are collapsed.
+var minPaneSizes = map[domain.DashboardPane]paneSize{
+	domain.PaneTotal:  {width: 12, height: 3},
+	domain.PaneModels: {width: 28, height: 4},
+	domain.PaneDaily:  {width: 18, height: 6},
+	domain.PaneTokens: {width: 22, height: 5},
+	domain.PaneBudget: {width: 22, height: 5},
+	domain.PaneStatus: {width: 20, height: 1},
+}
+
+// fixedPaneHeights are panes drawn at a fixed height without a border
+var fixedPaneHeights = map[domain.DashboardPane]int{
+	domain.PaneStatus: 1,
+}
+
+// paneTitles are shown in the top border of each boxed pane
+var paneTitles = map[domain.DashboardPane]string{
+	domain.PaneTotal:  "Total",
+	domain.PaneModels: "Models",
+	domain.PaneDaily:  "Daily",
+	domain.PaneTokens: "Tokens",
+	domain.PaneBudget: "Budget",
+}
+
+// DashboardPlugin implements the DisplayPlugin interface for a multi-pane dashboard
+type DashboardPlugin struct {
+	name        string
+	version     string
+	description string
+	enabled     bool
+	art         *RainbowTUIPlugin // Draws the big total
+}
+
+// NewDashboardPlugin creates a new dashboard display plugin
+func NewDashboardPlugin() *DashboardPlugin {
+	return &DashboardPlugin{
+		name:        "dashboard",
+		version:     "1.0.0",
+		description: "Multi-pane dashboard display plugin",
+		enabled:     false,
+		art:         NewRainbowTUIPlugin(),
+	}
+}
+
+// Name returns the plugin name
+func (d *DashboardPlugin) Name() string {
+	return d.name
+}
+
+// Version returns the plugin version
+func (d *DashboardPlugin) Version() string {
+	return d.version
+}
+
+// Description returns the plugin description
+func (d *DashboardPlugin) Description() string {
+	return d.description
+}
+
+// IsEnabled returns whether the plugin is enabled
+func (d *DashboardPlugin) IsEnabled() bool {
+	return d.enabled
+}
+
+// Initialize initializes the plugin with configuration
+func (d *DashboardPlugin) Initialize(config map[string]interface{}) error {
+	if err := d.art.Initialize(config); err != nil {
+		return err
+	}
+	d.enabled = true
+	return nil
+}
+
+// Shutdown shuts down the plugin
+func (d *DashboardPlugin) Shutdown() error {
+	d.enabled = false
+	return d.art.Shutdown()
+}
+
+// Render renders the display data as a dashboard of panes
+func (d *DashboardPlugin) Render(ctx context.Context, data *domain.DisplayData) (string, error) {
+	if !d.enabled {
+		return "", fmt.Errorf("plugin is not enabled")
+	}
+
+	if data == nil {
+		return "", fmt.Errorf("display data cannot be nil")
+	}
+
+	if data.Cost == nil {
+		return "", nil
+	}
+
+	width, height := data.Config.Size.Width, data.Config.Size.Height
+	layout := data.Config.Dashboard.Layout
+	if len(layout) == 0 {
+		layout = domain.DefaultDashboardConfig().Layout
+	}
+	layout = fitDashboardLayout(layout, width, height)
+
+	// A lone total is drawn like the rainbow display
+	if len(layout) == 1 && len(layout[0].Columns) == 1 && layout[0].Columns[0].Pane == domain.PaneTotal {
+		return d.art.Render(ctx, data)
+	}
+
+	sizes, _ := layoutSizes(layout, width, height)
+	renderer := terminal.NewRenderer(data.Config.ColorProfile)
+
+	rows := make([]string, len(layout))
+	for i, row := range layout {
+		cells := make([]string, len(row.Columns))
+		for j, column := range row.Columns {
+			cells[j] = d.renderPane(ctx, column.Pane, data, sizes[i][j], renderer)
+		}
+		rows[i] = lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+	}
+
+	return strings.Join(rows, "\n"), nil
+}
+
+// GetCapabilities returns the display capabilities
+func (d *DashboardPlugin) GetCapabilities() interfaces.DisplayCapabilities {
+	return interfaces.DisplayCapabilities{
+		MaxWidth:        400,
+		MaxHeight:       200,
+		SupportsColor:   true,
+		SupportsUnicode: true,
+		ShowsBreakdown:  true,
+	}
+}
+
+// ValidateDisplayConfig validates the display configuration
+func (d *DashboardPlugin) ValidateDisplayConfig(config *domain.DisplayConfig) error {
+	if config == nil {
+		return fmt.Errorf("display config cannot be nil")
+	}
+
+	capabilities := d.GetCapabilities()
+
+	// Check dimensions
+	if config.Size.Width > capabilities.MaxWidth {
+		return fmt.Errorf("width %d exceeds maximum %d", config.Size.Width, capabilities.MaxWidth)
+	}
+	if config.Size.Height > capabilities.MaxHeight {
+		return fmt.Errorf("height %d exceeds maximum %d", config.Size.Height, capabilities.MaxHeight)
+	}
+
+	return nil
+}
+
+// fitDashboardLayout drops the lowest priority panes until every remaining
+// pane gets its minimum size. The total is never dropped.
+func fitDashboardLayout(layout []domain.DashboardRow, width, height int) []domain.DashboardRow {
+	panes := domain.DashboardPanes()
+	for i := len(panes) - 1; i > 0; i-- {
+		if _, fits := layoutSizes(layout, width, height); fits {
+			return layout
+		}
+		layout = withoutPane(layout, panes[i])
+	}
+
+	if len(layout) == 0 {
+		return []domain.DashboardRow{{Columns: []domain.DashboardColumn{{Pane: domain.PaneTotal}}}}
+	}
+	return layout
+}
+
+// withoutPane returns the layout with the pane removed, dropping rows left empty
+func withoutPane(layout []domain.DashboardRow, pane domain.DashboardPane) []domain.DashboardRow {
+	var result []domain.DashboardRow
+	for _, row := range layout {
+		columns := slices.DeleteFunc(slices.Clone(row.Columns), func(column domain.DashboardColumn) bool {
+			return column.Pane == pane
+		})
+		if len(columns) > 0 {
+			row.Columns = columns
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// layoutSizes computes the size of every pane and reports whether all of them
+// get at least their minimum size
+func layoutSizes(layout []domain.DashboardRow, width, height int) ([][]paneSize, bool) {
+	// Rows of fixed-height panes take exactly their height, the others share the rest
+	rowHeights := make([]int, len(layout))
+	weights := make([]int, len(layout))
+	flexible := height
+	for i, row := range layout {
+		if fixed := fixedRowHeight(row); fixed > 0 {
+			rowHeights[i] = fixed
+			flexible -= fixed
+			continue
+		}
+		weights[i] = max(1, row.Weight)
+	}
+	if flexible < 0 {
+		return nil, false
+	}
+	for i, share := range distribute(flexible, weights) {
+		if weights[i] > 0 {
+			rowHeights[i] = share
+		}
+	}
+
+	fits := true
+	sizes := make([][]paneSize, len(layout))
+	for i, row := range layout {
+		columnWeights := make([]int, len(row.Columns))
+		for j, column := range row.Columns {
+			columnWeights[j] = max(1, column.Weight)
+		}
+
+		sizes[i] = make([]paneSize, len(row.Columns))
+		for j, columnWidth := range distribute(width, columnWeights) {
+			size := paneSize{width: columnWidth, height: rowHeights[i]}
+			minimum := minPaneSizes[row.Columns[j].Pane]
+			if size.width < minimum.width || size.height < minimum.height {
+				fits = false
+			}
+			sizes[i][j] = size
+		}
+	}
+
+	return sizes, fits
+}
+
+// fixedRowHeight returns the height of a row made only of fixed-height panes, or 0
+func fixedRowHeight(row domain.DashboardRow) int {
+	height := 0
+	for _, column := range row.Columns {
+		fixed, exists := fixedPaneHeights[column.Pane]
+		if !exists {
+			return 0
+		}
+		height = max(height, fixed)
+	}
+	return height
+}
+
+// distribute splits total into parts proportional to the weights.
+// Parts with zero weight get nothing; the remainder goes to the first parts.
+func distribute(total int, weights []int) []int {
+	sum := 0
+	for _, weight := range weights {
+		sum += weight
+	}
+
+	parts := make([]int, len(weights))
+	if sum == 0 || total <= 0 {
+		return parts
+	}
+
+	remaining := total
+	for i, weight := range weights {
+		parts[i] = total * weight / sum
+		remaining -= parts[i]
+	}
+	for i := 0; remaining > 0; i = (i + 1) % len(parts) {
+		if weights[i] > 0 {
+			parts[i]++
+			remaining--
+		}
+	}
+
+	return parts
+}
+
+// renderPane renders a pane at exactly the given size
+func (d *DashboardPlugin) renderPane(ctx context.Context, pane domain.DashboardPane, data *domain.DisplayData, size paneSize, renderer *lipgloss.Renderer) string {
+	if _, fixed := fixedPaneHeights[pane]; fixed {
+		return clipBlock(d.paneContent(ctx, pane, data, size.width, size.height, renderer), size.width, size.height)
+	}
+
+	innerWidth, innerHeight := size.width-2, size.height-2
+	content := clipBlock(d.paneContent(ctx, pane, data, innerWidth, innerHeight, renderer), innerWidth, innerHeight)
+	return drawBox(paneTitles[pane], content, size.width, size.height, renderer.NewStyle().Faint(true))
+}
+
+// drawBox surrounds content with a rounded border carrying a title
+func drawBox(title string, content string, width, height int, borderStyle lipgloss.Style) string {
+	border := lipgloss.RoundedBorder()
+	innerWidth := width - 2
+
+	label := ansi.Truncate(border.Top+" "+title+" ", innerWidth, "")
+	top := border.TopLeft + label + strings.Repeat(border.Top, max(0, innerWidth-ansi.StringWidth(label))) + border.TopRight
+	bottom := border.BottomLeft + strings.Repeat(border.Bottom, innerWidth) + border.BottomRight
+
+	lines := []string{borderStyle.Render(top)}
+	for _, line := range strings.Split(content, "\n")[:height-2] {
+		lines = append(lines, borderStyle.Render(border.Left)+line+borderStyle.Render(border.Right))
+	}
+	lines = append(lines, borderStyle.Render(bottom))
+
+	return strings.Join(lines, "\n")
+}
+
+// clipBlock cuts or pads text to exactly the given number of lines and cells
+func clipBlock(text string, width, height int) string {
+	lines := strings.Split(text, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	lines = lines[:max(0, height)]
+
+	for i, line := range lines {
+		line = ansi.Truncate(line, width, "")
+		lines[i] = line + strings.Repeat(" ", max(0, width-ansi.StringWidth(line)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,229 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Dashboard colors and proportions
+const (
+	barColor         = "#5FAFFF"
+	budgetOkColor    = "#5FFF87"
+	budgetWarnColor  = "#FFD75F"
+	budgetOverColor  = "#FF5F5F"
+	budgetWarnRatio  = 0.75 // Share of the budget from which the gauge turns yellow
+	modelCostWidth   = 10
+	modelShareWidth  = 6
+	dailyBarSpacing  = 2 // Columns per day: the bar and a gap
+	dailyLabelFormat = "01-02"
+)
+
+// paneContent renders the inside of a pane, without its border
+func (d *DashboardPlugin) paneContent(ctx context.Context, pane domain.DashboardPane, data *domain.DisplayData, width, height int, renderer *lipgloss.Renderer) string {
+	switch pane {
+	case domain.PaneTotal:
+		return d.totalContent(ctx, data, width, height)
+	case domain.PaneModels:
+		return modelsContent(data, width, height, renderer)
+	case domain.PaneDaily:
+		return dailyContent(data, width, height, renderer)
+	case domain.PaneTokens:
+		return tokensContent(data, width, renderer)
+	case domain.PaneBudget:
+		return budgetContent(data, width, renderer)
+	case domain.PaneStatus:
+		return statusContent(data, width, renderer)
+	}
+	return ""
+}
+
+// totalContent draws the total as rainbow art, falling back to the small letters
+// and then to plain colored text when the art does not fit
+func (d *DashboardPlugin) totalContent(ctx context.Context, data *domain.DisplayData, width, height int) string {
+	costText := domain.FormatCost(data.Cost.TotalCost, data.Cost.Currency, data.Config.Format)
+
+	for _, artHeight := range []int{height, min(height, largeArtMinHeight-1)} {
+		asciiArt := d.art.generateASCIIArt(costText, width, artHeight)
+		if lipgloss.Width(asciiArt) > width || lipgloss.Height(asciiArt) > artHeight {
+			continue
+		}
+
+		config := *data.Config
+		config.Size = domain.DisplaySize{Width: width, Height: artHeight}
+		paneData := *data
+		paneData.Config = &config
+
+		output, err := d.art.Render(ctx, &paneData)
+		if err == nil {
+			return lipgloss.PlaceVertical(height, lipgloss.Center, output)
+		}
+	}
+
+	text := d.art.applyRainbowColors(costText, data.Animation, data.Config.ColorProfile, data.Highlight)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, text)
+}
+
+// modelsContent lists the models by cost with their share of the total
+func modelsContent(data *domain.DisplayData, width, height int, renderer *lipgloss.Renderer) string {
+	if len(data.Cost.ModelBreakdown) == 0 {
+		return renderer.NewStyle().Faint(true).Render("No model data")
+	}
+
+	type modelCost struct {
+		name string
+		cost float64
+	}
+	models := make([]modelCost, 0, len(data.Cost.ModelBreakdown))
+	for name, cost := range data.Cost.ModelBreakdown {
+		models = append(models, modelCost{name, cost})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].cost != models[j].cost {
+			return models[i].cost > models[j].cost
+		}
+		return models[i].name < models[j].name
+	})
+
+	nameWidth := max(1, width-modelCostWidth-modelShareWidth-2)
+	row := func(name, cost, share string) string {
+		return fmt.Sprintf("%-*s %*s %*s", nameWidth, ansi.Truncate(name, nameWidth, "…"), modelCostWidth, cost, modelShareWidth, share)
+	}
+
+	lines := []string{renderer.NewStyle().Faint(true).Render(row("Model", "Cost", "Share"))}
+	visible := height - 1
+	if len(models) > visible {
+		visible = max(0, visible-1) // Keep a line for the hidden count
+	}
+	for _, model := range models[:min(visible, len(models))] {
+		share := 0.0
+		if data.Cost.TotalCost > 0 {
+			share = model.cost / data.Cost.TotalCost * 100
+		}
+		lines = append(lines, row(model.name, domain.FormatCost(model.cost, data.Cost.Currency, data.Config.Format), fmt.Sprintf("%.1f%%", share)))
+	}
+	if hidden := len(models) - visible; hidden > 0 {
+		lines = append(lines, renderer.NewStyle().Faint(true).Render(fmt.Sprintf("+%d more", hidden)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// dailyContent draws a bar chart of the daily costs ending today
+func dailyContent(data *domain.DisplayData, width, height int, renderer *lipgloss.Renderer) string {
+	labelStyle := renderer.NewStyle().Faint(true)
+	if len(data.Cost.Daily) == 0 {
+		return labelStyle.Render("No daily data")
+	}
+
+	days := domain.DailySeries(data.Cost.Daily, data.CurrentTime(), max(1, (width+1)/dailyBarSpacing))
+	highest := 0.0
+	for _, day := range days {
+		highest = max(highest, day.Cost)
+	}
+
+	lines := []string{labelStyle.Render("max " + domain.FormatCost(highest, data.Cost.Currency, data.Config.Format))}
+	barStyle := renderer.NewStyle().Foreground(terminal.AdaptColor(barColor, data.Config.ColorProfile))
+	for _, row := range terminal.BarRows(days, height-2, dailyBarSpacing-1) {
+		lines = append(lines, barStyle.Render(row))
+	}
+
+	first := days[0].Date.Format(dailyLabelFormat)
+	last := days[len(days)-1].Date.Format(dailyLabelFormat)
+	gap := max(1, width-len(first)-len(last))
+	lines = append(lines, labelStyle.Render(first+strings.Repeat(" ", gap)+last))
+
+	return strings.Join(lines, "\n")
+}
+
+// tokensContent summarizes input, output and total tokens
+func tokensContent(data *domain.DisplayData, width int, renderer *lipgloss.Renderer) string {
+	tokens := data.Cost.Tokens
+	if tokens.Total() == 0 {
+		return renderer.NewStyle().Faint(true).Render("No token data")
+	}
+
+	labelStyle := renderer.NewStyle().Faint(true)
+	line := func(label string, value int) string {
+		number := domain.FormatNumber(float64(value), 0, data.Config.Format)
+		return labelStyle.Render(label) + strings.Repeat(" ", max(1, width-len(label)-len(number))) + number
+	}
+
+	return strings.Join([]string{
+		line("Input", tokens.Input),
+		line("Output", tokens.Output),
+		line("Total", tokens.Total()),
+	}, "\n")
+}
+
+// budgetContent draws a gauge of the month-to-date cost against the monthly budget,
+// whichever period and model are shown
+func budgetContent(data *domain.DisplayData, width int, renderer *lipgloss.Renderer) string {
+	labelStyle := renderer.NewStyle().Faint(true)
+	budget := data.Config.Dashboard.Budget
+	if budget <= 0 {
+		return labelStyle.Render("No budget set")
+	}
+
+	// Sources without a daily series only report the overall total
+	cost := data.UnfilteredCost()
+	spent := cost.TotalCost
+	if len(cost.Daily) > 0 {
+		spent = domain.FilterCostData(cost, domain.PeriodMonth, "", data.CurrentTime()).TotalCost
+	}
+	ratio := spent / budget
+
+	color := budgetOkColor
+	switch {
+	case ratio >= 1:
+		color = budgetOverColor
+	case ratio >= budgetWarnRatio:
+		color = budgetWarnColor
+	}
+
+	filled := max(0, min(width, int(ratio*float64(width)+0.5)))
+	gauge := renderer.NewStyle().Foreground(terminal.AdaptColor(color, data.Config.ColorProfile)).Render(strings.Repeat("█", filled)) +
+		labelStyle.Render(strings.Repeat("░", width-filled))
+
+	percent := fmt.Sprintf("%.0f%%", ratio*100)
+	return strings.Join([]string{
+		labelStyle.Render("This month") + strings.Repeat(" ", max(1, width-len("This month")-len(percent))) + percent,
+		gauge,
+		domain.FormatCost(spent, cost.Currency, data.Config.Format) + " of " + domain.FormatCost(budget, cost.Currency, data.Config.Format),
+	}, "\n")
+}
+
+// statusContent renders the one-line status footer
+func statusContent(data *domain.DisplayData, width int, renderer *lipgloss.Renderer) string {
+	var parts []string
+	if !data.LastUpdated.IsZero() {
+		parts = append(parts, "Updated "+data.LastUpdated.Format("15:04:05"))
+	}
+
+	currency := data.Cost.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	parts = append(parts, currency)
+
+	switch models := len(data.Cost.ModelBreakdown); models {
+	case 0:
+	case 1:
+		parts = append(parts, "1 model")
+	default:
+		parts = append(parts, fmt.Sprintf("%d models", models))
+	}
+
+	if conversion := data.Cost.Conversion; conversion != nil {
+		parts = append(parts, fmt.Sprintf("1 %s = %s %s", conversion.From, domain.FormatNumber(conversion.Rate, 4, domain.DefaultNumberFormat()), conversion.To))
+	}
+
+	status := renderer.NewStyle().Faint(true).Render(strings.Join(parts, " · "))
+	return lipgloss.PlaceHorizontal(width, lipgloss.Center, status)
+}
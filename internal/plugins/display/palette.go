@@ -0,0 +1,120 @@
+package display
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+)
+
+// paletteCache memoizes the escape sequence of each palette color per color profile
+type paletteCache struct {
+	mu        sync.Mutex
+	profile   domain.ColorProfile
+	sequences map[string]string
+}
+
+// newPaletteCache creates an empty palette cache
+func newPaletteCache() *paletteCache {
+	return &paletteCache{
+		sequences: make(map[string]string),
+	}
+}
+
+// sequencesFor returns the escape sequence for each color, in order.
+// Colors that degrade to the same terminal color share the same sequence,
+// which lets adjacent cells coalesce into a single run.
+func (p *paletteCache) sequencesFor(colors []string, profile domain.ColorProfile) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if profile != p.profile {
+		p.profile = profile
+		p.sequences = make(map[string]string)
+	}
+
+	sequences := make([]string, len(colors))
+	for i, color := range colors {
+		sequence, exists := p.sequences[color]
+		if !exists {
+			sequence = terminal.ForegroundSequence(color, profile)
+			p.sequences[color] = sequence
+		}
+		sequences[i] = sequence
+	}
+
+	return sequences
+}
+
+// runWriter writes text while coalescing consecutive cells of the same color
+// into a single escape sequence. Spaces are never styled on their own: they
+// join the surrounding run when the color continues, and are written bare otherwise.
+type runWriter struct {
+	out           strings.Builder
+	run           strings.Builder
+	runSequence   string
+	pendingSpaces int
+}
+
+// writeSpace queues a space until the next colored cell decides where it belongs
+func (w *runWriter) writeSpace() {
+	w.pendingSpaces++
+}
+
+// writeCell writes a non-space cell in the color selected by the escape sequence
+func (w *runWriter) writeCell(char rune, sequence string) {
+	if sequence != w.runSequence {
+		w.flushRun()
+		w.runSequence = sequence
+	}
+
+	if w.pendingSpaces > 0 {
+		target := &w.run
+		if w.run.Len() == 0 {
+			target = &w.out
+		}
+		target.WriteString(strings.Repeat(" ", w.pendingSpaces))
+		w.pendingSpaces = 0
+	}
+
+	w.run.WriteRune(char)
+}
+
+// writeNewline ends the current line, leaving trailing spaces unstyled
+func (w *runWriter) writeNewline() {
+	w.endLine()
+	w.out.WriteByte('\n')
+}
+
+// String finishes the output and returns it
+func (w *runWriter) String() string {
+	w.endLine()
+	return w.out.String()
+}
+
+// endLine flushes the open run and any trailing spaces
+func (w *runWriter) endLine() {
+	w.flushRun()
+	if w.pendingSpaces > 0 {
+		w.out.WriteString(strings.Repeat(" ", w.pendingSpaces))
+		w.pendingSpaces = 0
+	}
+	w.runSequence = ""
+}
+
+// flushRun writes the open run wrapped in its escape sequence
+func (w *runWriter) flushRun() {
+	if w.run.Len() == 0 {
+		return
+	}
+
+	if w.runSequence == "" {
+		w.out.WriteString(w.run.String())
+	} else {
+		w.out.WriteString(w.runSequence)
+		w.out.WriteString(w.run.String())
+		w.out.WriteString(terminal.ResetSequence)
+	}
+	w.run.Reset()
+}
@@ -3,11 +3,21 @@ package display
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
-	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightColor is the color of highlighted characters, such as digits that just changed
+const highlightColor = "#FFFFFF"
+
+// Smallest area drawn with the large letter patterns
+const (
+	largeArtMinWidth  = 40
+	largeArtMinHeight = 12
 )
 
 // RainbowTUIPlugin implements the DisplayPlugin interface for rainbow TUI display
@@ -16,6 +26,7 @@ type RainbowTUIPlugin struct {
 	version     string
 	description string
 	enabled     bool
+	palette     *paletteCache
 }
 
 // NewRainbowTUIPlugin creates a new rainbow TUI display plugin
@@ -25,6 +36,7 @@ func NewRainbowTUIPlugin() *RainbowTUIPlugin {
 		version:     "1.0.0",
 		description: "Rainbow TUI display plugin",
 		enabled:     false,
+		palette:     newPaletteCache(),
 	}
 }
 
@@ -75,17 +87,68 @@ func (r *RainbowTUIPlugin) Render(ctx context.Context, data *domain.DisplayData)
 	}
 
 	// Generate ASCII art for the cost
-	asciiArt := r.generateASCIIArt(data.Cost.TotalCost, data.Config.Size.Width, data.Config.Size.Height)
+	costText := domain.FormatCost(data.Cost.TotalCost, data.Cost.Currency, data.Config.Format)
+	asciiArt := r.generateASCIIArt(costText, data.Config.Size.Width, data.Config.Size.Height)
 	centeredAsciiArt := r.centerASCIIArt(asciiArt, data.Config.Size.Width, data.Config.Size.Height)
 
 	// Apply rainbow colors if animation is available
 	if data.Animation != nil {
-		return r.applyRainbowColors(centeredAsciiArt, data.Animation), nil
+		highlight := r.highlightColumns(costText, data.Highlight, data.Config.Size.Width, data.Config.Size.Height)
+		return r.applyRainbowColors(centeredAsciiArt, data.Animation, data.Config.ColorProfile, highlight), nil
 	}
 
 	return centeredAsciiArt, nil
 }
 
+// RenderGrid renders the display data as a grid of colored cells instead of
+// terminal output, for exporting the art as images
+func (r *RainbowTUIPlugin) RenderGrid(ctx context.Context, data *domain.DisplayData) (*domain.ArtGrid, error) {
+	if !r.enabled {
+		return nil, fmt.Errorf("plugin is not enabled")
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("display data cannot be nil")
+	}
+
+	if data.Cost == nil {
+		return &domain.ArtGrid{}, nil
+	}
+
+	width, height := data.Config.Size.Width, data.Config.Size.Height
+	costText := domain.FormatCost(data.Cost.TotalCost, data.Cost.Currency, data.Config.Format)
+	asciiArt := r.centerASCIIArt(r.generateASCIIArt(costText, width, height), width, height)
+
+	colors := []string{highlightColor}
+	if data.Animation != nil && len(data.Animation.Colors) > 0 {
+		colors = data.Animation.Colors
+	}
+	highlight := r.highlightColumns(costText, data.Highlight, width, height)
+
+	grid := &domain.ArtGrid{}
+	for lineIndex, line := range strings.Split(asciiArt, "\n") {
+		row := make([]domain.ArtCell, 0, utf8.RuneCountInString(line))
+		column := 0
+		for i, char := range line {
+			cell := domain.ArtCell{Char: char}
+			switch {
+			case char == ' ':
+			case column < len(highlight) && highlight[column]:
+				cell.Color = highlightColor
+			default:
+				cell.Color = colors[paletteIndex(lineIndex, line, i, len(colors))]
+			}
+			row = append(row, cell)
+			column++
+		}
+		grid.Rows = append(grid.Rows, row)
+		grid.Width = max(grid.Width, len(row))
+	}
+	grid.Height = len(grid.Rows)
+
+	return grid, nil
+}
+
 // GetCapabilities returns the display capabilities
 func (r *RainbowTUIPlugin) GetCapabilities() interfaces.DisplayCapabilities {
 	return interfaces.DisplayCapabilities{
@@ -115,31 +178,19 @@ func (r *RainbowTUIPlugin) ValidateDisplayConfig(config *domain.DisplayConfig) e
 	return nil
 }
 
-// generateASCIIArt converts a dollar amount to ASCII art
-func (r *RainbowTUIPlugin) generateASCIIArt(amount float64, width, height int) string {
-	text := fmt.Sprintf("$%.2f", amount)
-
-	// Choose pattern set based on available size
-	var patterns map[rune][]string
-	var numRows int
-
-	// Use small patterns for smaller areas
-	if width < 40 || height < 12 {
-		patterns = r.getSmallLetterPatterns()
-		numRows = 7
-	} else {
-		patterns = r.getLargeLetterPatterns()
-		numRows = 10
-	}
+// generateASCIIArt converts formatted cost text to ASCII art
+func (r *RainbowTUIPlugin) generateASCIIArt(text string, width, height int) string {
+	chars := []rune(text)
+	patterns, numRows := r.letterPatterns(width, height)
 
 	// Build ASCII art line by line with spacing between characters
 	lines := make([]string, numRows)
-	for charIndex, char := range text {
+	for charIndex, char := range chars {
 		if pattern, exists := patterns[char]; exists {
 			for i, line := range pattern {
 				lines[i] += line
 				// Add spacing between characters (except for the last character)
-				if charIndex < len(text)-1 {
+				if charIndex < len(chars)-1 {
 					lines[i] += "  " // 2 spaces between characters
 				}
 			}
@@ -149,6 +200,53 @@ func (r *RainbowTUIPlugin) generateASCIIArt(amount float64, width, height int) s
 	return strings.Join(lines, "\n")
 }
 
+// letterPatterns chooses the pattern set and its row count based on available size
+func (r *RainbowTUIPlugin) letterPatterns(width, height int) (map[rune][]string, int) {
+	// Use small patterns for smaller areas
+	if width < largeArtMinWidth || height < largeArtMinHeight {
+		return r.getSmallLetterPatterns(), 7
+	}
+	return r.getLargeLetterPatterns(), 10
+}
+
+// highlightColumns maps highlighted characters of the text to the columns of the
+// centered ASCII art, or returns nil when nothing is highlighted
+func (r *RainbowTUIPlugin) highlightColumns(text string, highlight []bool, width, height int) []bool {
+	if !slices.Contains(highlight, true) {
+		return nil
+	}
+
+	chars := []rune(text)
+	patterns, _ := r.letterPatterns(width, height)
+
+	var columns []bool
+	for charIndex, char := range chars {
+		pattern, exists := patterns[char]
+		if !exists {
+			continue
+		}
+
+		glyphWidth := 0
+		for _, line := range pattern {
+			glyphWidth = max(glyphWidth, utf8.RuneCountInString(line))
+		}
+		highlighted := charIndex < len(highlight) && highlight[charIndex]
+		for i := 0; i < glyphWidth; i++ {
+			columns = append(columns, highlighted)
+		}
+		if charIndex < len(chars)-1 {
+			columns = append(columns, false, false)
+		}
+	}
+
+	// Shift by the horizontal padding added when centering
+	if width > len(columns) {
+		columns = append(make([]bool, (width-len(columns))/2), columns...)
+	}
+
+	return columns
+}
+
 // centerASCIIArt centers ASCII art both horizontally and vertically within given dimensions
 func (r *RainbowTUIPlugin) centerASCIIArt(asciiArt string, width, height int) string {
 	lines := strings.Split(asciiArt, "\n")
@@ -204,29 +302,53 @@ func (r *RainbowTUIPlugin) centerASCIIArt(asciiArt string, width, height int) st
 	return result.String()
 }
 
-// applyRainbowColors applies rainbow colors to text based on animation frame
-func (r *RainbowTUIPlugin) applyRainbowColors(text string, animation *domain.AnimationFrame) string {
-	if animation == nil || len(animation.Colors) == 0 {
+// applyRainbowColors applies rainbow colors to text based on animation frame,
+// mapping each palette color to the nearest color the terminal profile supports.
+// Runs of identically colored cells share one escape sequence and spaces are left unstyled.
+// Cells in highlighted columns are drawn in the highlight color instead.
+func (r *RainbowTUIPlugin) applyRainbowColors(text string, animation *domain.AnimationFrame, profile domain.ColorProfile, highlight []bool) string {
+	if animation == nil || len(animation.Colors) == 0 || profile == domain.ColorProfileNone {
 		return text
 	}
 
-	var styledText strings.Builder
+	if profile == "" {
+		profile = domain.ColorProfileTrueColor
+	}
+	sequences := r.palette.sequencesFor(animation.Colors, profile)
+	highlightSequence := r.palette.sequencesFor([]string{highlightColor}, profile)[0]
+
+	var writer runWriter
 	lines := strings.Split(text, "\n")
 
 	for lineIndex, line := range lines {
+		column := 0
 		for i, char := range line {
-			colorIndex := (lineIndex*len(line) + i) % len(animation.Colors)
-			color := animation.Colors[colorIndex]
+			highlighted := column < len(highlight) && highlight[column]
+			column++
+
+			if char == ' ' {
+				writer.writeSpace()
+				continue
+			}
 
-			charStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
-			styledText.WriteString(charStyle.Render(string(char)))
+			if highlighted {
+				writer.writeCell(char, highlightSequence)
+				continue
+			}
+
+			writer.writeCell(char, sequences[paletteIndex(lineIndex, line, i, len(sequences))])
 		}
 		if lineIndex < len(lines)-1 {
-			styledText.WriteString("\n")
+			writer.writeNewline()
 		}
 	}
 
-	return styledText.String()
+	return writer.String()
+}
+
+// paletteIndex returns the palette color of the character at byte offset i of a line
+func paletteIndex(lineIndex int, line string, i, paletteSize int) int {
+	return (lineIndex*len(line) + i) % paletteSize
 }
 
 // getSmallLetterPatterns returns small ASCII art patterns for small screens
@@ -340,6 +462,285 @@ func (r *RainbowTUIPlugin) getSmallLetterPatterns() map[rune][]string {
 			" ███  ",
 			" ███  ",
 		},
+		',': {
+			"      ",
+			"      ",
+			"      ",
+			"      ",
+			" ███  ",
+			"  ██  ",
+			" ██   ",
+		},
+		'-': {
+			"       ",
+			"       ",
+			"       ",
+			"███████",
+			"       ",
+			"       ",
+			"       ",
+		},
+		'€': {
+			"  ██████ ",
+			" ███     ",
+			"███████  ",
+			" ███     ",
+			"███████  ",
+			" ███     ",
+			"  ██████ ",
+		},
+		'¥': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"█████████",
+			"   ███   ",
+			"█████████",
+			"   ███   ",
+		},
+		'£': {
+			"  ██████ ",
+			" ███   ██",
+			" ███     ",
+			"███████  ",
+			" ███     ",
+			" ███     ",
+			"█████████",
+		},
+		'A': {
+			" ███████ ",
+			"███   ███",
+			"███   ███",
+			"█████████",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+		},
+		'B': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+		},
+		'C': {
+			" ███████ ",
+			"███   ███",
+			"███      ",
+			"███      ",
+			"███      ",
+			"███   ███",
+			" ███████ ",
+		},
+		'D': {
+			"███████  ",
+			"███  ███ ",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███  ███ ",
+			"███████  ",
+		},
+		'E': {
+			"█████████",
+			"███      ",
+			"███      ",
+			"████████ ",
+			"███      ",
+			"███      ",
+			"█████████",
+		},
+		'F': {
+			"█████████",
+			"███      ",
+			"███      ",
+			"████████ ",
+			"███      ",
+			"███      ",
+			"███      ",
+		},
+		'G': {
+			" ███████ ",
+			"███   ███",
+			"███      ",
+			"███  ████",
+			"███   ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'H': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"█████████",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+		},
+		'I': {
+			" ███████ ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			" ███████ ",
+		},
+		'J': {
+			"   ██████",
+			"      ███",
+			"      ███",
+			"      ███",
+			"      ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'K': {
+			"███   ███",
+			"███  ███ ",
+			"███ ███  ",
+			"██████   ",
+			"███ ███  ",
+			"███  ███ ",
+			"███   ███",
+		},
+		'L': {
+			"███      ",
+			"███      ",
+			"███      ",
+			"███      ",
+			"███      ",
+			"███      ",
+			"█████████",
+		},
+		'M': {
+			"███   ███",
+			"████ ████",
+			"█████████",
+			"███ █ ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+		},
+		'N': {
+			"███   ███",
+			"████  ███",
+			"█████ ███",
+			"███ █████",
+			"███  ████",
+			"███   ███",
+			"███   ███",
+		},
+		'O': {
+			" ███████ ",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'P': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+			"███      ",
+			"███      ",
+			"███      ",
+		},
+		'Q': {
+			" ███████ ",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███ █ ███",
+			"███  ███ ",
+			" ████ ███",
+		},
+		'R': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+			"███ ███  ",
+			"███  ███ ",
+			"███   ███",
+		},
+		'S': {
+			" ███████ ",
+			"███   ███",
+			"███      ",
+			" ███████ ",
+			"      ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'T': {
+			"█████████",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+		},
+		'U': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'V': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+		},
+		'W': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███ █ ███",
+			"█████████",
+			"████ ████",
+			"███   ███",
+		},
+		'X': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+			"  █████  ",
+			" ███ ███ ",
+			"███   ███",
+		},
+		'Y': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+		},
+		'Z': {
+			"█████████",
+			"      ███",
+			"     ███ ",
+			"   ███   ",
+			" ███     ",
+			"███      ",
+			"█████████",
+		},
 		' ': {
 			"         ",
 			"         ",
@@ -499,6 +900,378 @@ func (r *RainbowTUIPlugin) getLargeLetterPatterns() map[rune][]string {
 			" ██████  ",
 			" ██████  ",
 		},
+		',': {
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			" ██████  ",
+			" ██████  ",
+			"   ████  ",
+			"  ███    ",
+		},
+		'-': {
+			"            ",
+			"            ",
+			"            ",
+			"            ",
+			"████████████",
+			"████████████",
+			"            ",
+			"            ",
+			"            ",
+			"            ",
+		},
+		'€': {
+			"    ██████████",
+			"  ████        ",
+			" ████         ",
+			"████████████  ",
+			"████          ",
+			"████          ",
+			"████████████  ",
+			" ████         ",
+			"  ████        ",
+			"    ██████████",
+		},
+		'¥': {
+			"████      ████",
+			" ████    ████ ",
+			"  ████  ████  ",
+			"   ████████   ",
+			"██████████████",
+			"     ████     ",
+			"██████████████",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+		},
+		'£': {
+			"    █████████ ",
+			"   ████    ███",
+			"   ████       ",
+			"   ████       ",
+			"██████████    ",
+			"   ████       ",
+			"   ████       ",
+			"   ████       ",
+			"  ████        ",
+			"██████████████",
+		},
+		'A': {
+			"  ██████████  ",
+			" ████    ████ ",
+			"████      ████",
+			"████      ████",
+			"██████████████",
+			"██████████████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+		},
+		'B': {
+			"████████████  ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████████████  ",
+			"████████████  ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████████████  ",
+		},
+		'C': {
+			"  ███████████ ",
+			" ████     ████",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			" ████     ████",
+			"  ███████████ ",
+		},
+		'D': {
+			"██████████    ",
+			"████    ████  ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████    ████  ",
+			"██████████    ",
+		},
+		'E': {
+			"██████████████",
+			"████          ",
+			"████          ",
+			"████          ",
+			"███████████   ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"██████████████",
+		},
+		'F': {
+			"██████████████",
+			"████          ",
+			"████          ",
+			"████          ",
+			"███████████   ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+		},
+		'G': {
+			"  ███████████ ",
+			" ████     ████",
+			"████          ",
+			"████          ",
+			"████   ███████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			" ████     ████",
+			"  ███████████ ",
+		},
+		'H': {
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"██████████████",
+			"██████████████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+		},
+		'I': {
+			"  ██████████  ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"  ██████████  ",
+		},
+		'J': {
+			"     █████████",
+			"          ████",
+			"          ████",
+			"          ████",
+			"          ████",
+			"          ████",
+			"          ████",
+			"████      ████",
+			" ████    ████ ",
+			"  ██████████  ",
+		},
+		'K': {
+			"████      ████",
+			"████     ████ ",
+			"████    ████  ",
+			"████  ████    ",
+			"████████      ",
+			"████████      ",
+			"████  ████    ",
+			"████    ████  ",
+			"████     ████ ",
+			"████      ████",
+		},
+		'L': {
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"██████████████",
+		},
+		'M': {
+			"████      ████",
+			"█████    █████",
+			"██████  ██████",
+			"████ ████ ████",
+			"████  ██  ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+		},
+		'N': {
+			"████      ████",
+			"█████     ████",
+			"██████    ████",
+			"████ ███  ████",
+			"████  ███ ████",
+			"████   ███████",
+			"████    ██████",
+			"████     █████",
+			"████      ████",
+			"████      ████",
+		},
+		'O': {
+			"  ██████████  ",
+			" ████    ████ ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			" ████    ████ ",
+			"  ██████████  ",
+		},
+		'P': {
+			"████████████  ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████████████  ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+		},
+		'Q': {
+			"  ██████████  ",
+			" ████    ████ ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████  ██  ████",
+			"████   ██ ████",
+			" ████   █████ ",
+			"  ████████ ███",
+		},
+		'R': {
+			"████████████  ",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████████████  ",
+			"████████      ",
+			"████  ████    ",
+			"████    ████  ",
+			"████     ████ ",
+			"████      ████",
+		},
+		'S': {
+			"  ███████████ ",
+			" ████     ████",
+			"████          ",
+			" ████         ",
+			"  ██████████  ",
+			"         ████ ",
+			"          ████",
+			"          ████",
+			"████     ████ ",
+			" ███████████  ",
+		},
+		'T': {
+			"██████████████",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+		},
+		'U': {
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			" ████    ████ ",
+			"  ██████████  ",
+		},
+		'V': {
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			" ████    ████ ",
+			" ████    ████ ",
+			"  ████  ████  ",
+			"  ████  ████  ",
+			"   ████████   ",
+			"     ████     ",
+		},
+		'W': {
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████      ████",
+			"████  ██  ████",
+			"████ ████ ████",
+			"██████  ██████",
+			"█████    █████",
+			"████      ████",
+		},
+		'X': {
+			"████      ████",
+			" ████    ████ ",
+			"  ████  ████  ",
+			"   ████████   ",
+			"     ████     ",
+			"     ████     ",
+			"   ████████   ",
+			"  ████  ████  ",
+			" ████    ████ ",
+			"████      ████",
+		},
+		'Y': {
+			"████      ████",
+			" ████    ████ ",
+			"  ████  ████  ",
+			"   ████████   ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+		},
+		'Z': {
+			"██████████████",
+			"          ████",
+			"         ████ ",
+			"        ████  ",
+			"      ████    ",
+			"    ████      ",
+			"  ████        ",
+			" ████         ",
+			"████          ",
+			"██████████████",
+		},
 		' ': {
 			"              ",
 			"              ",
@@ -0,0 +1,189 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// TablePlugin implements the DisplayPlugin interface for a plain cost table
+type TablePlugin struct {
+	name        string
+	version     string
+	description string
+	enabled     bool
+}
+
+// NewTablePlugin creates a new table display plugin
+func NewTablePlugin() *TablePlugin {
+	return &TablePlugin{
+		name:        "table",
+		version:     "1.0.0",
+		description: "Plain table display plugin",
+		enabled:     false,
+	}
+}
+
+// Name returns the plugin name
+func (t *TablePlugin) Name() string {
+	return t.name
+}
+
+// Version returns the plugin version
+func (t *TablePlugin) Version() string {
+	return t.version
+}
+
+// Description returns the plugin description
+func (t *TablePlugin) Description() string {
+	return t.description
+}
+
+// IsEnabled returns whether the plugin is enabled
+func (t *TablePlugin) IsEnabled() bool {
+	return t.enabled
+}
+
+// Initialize initializes the plugin with configuration
+func (t *TablePlugin) Initialize(config map[string]interface{}) error {
+	t.enabled = true
+	return nil
+}
+
+// Shutdown shuts down the plugin
+func (t *TablePlugin) Shutdown() error {
+	t.enabled = false
+	return nil
+}
+
+// Render renders the cost per model as a plain table centered on screen
+func (t *TablePlugin) Render(ctx context.Context, data *domain.DisplayData) (string, error) {
+	if !t.enabled {
+		return "", fmt.Errorf("plugin is not enabled")
+	}
+
+	if data == nil {
+		return "", fmt.Errorf("display data cannot be nil")
+	}
+
+	if data.Cost == nil {
+		return "", nil
+	}
+
+	cost := data.Cost
+	format := data.Config.Format
+
+	models := make([]string, 0, len(cost.ModelBreakdown))
+	for name := range cost.ModelBreakdown {
+		models = append(models, name)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if cost.ModelBreakdown[models[i]] != cost.ModelBreakdown[models[j]] {
+			return cost.ModelBreakdown[models[i]] > cost.ModelBreakdown[models[j]]
+		}
+		return models[i] < models[j]
+	})
+
+	rows := make([][]string, 0, len(models)+1)
+	for _, name := range models {
+		share := 0.0
+		if cost.TotalCost > 0 {
+			share = cost.ModelBreakdown[name] / cost.TotalCost * 100
+		}
+		rows = append(rows, []string{name, domain.FormatCost(cost.ModelBreakdown[name], cost.Currency, format), fmt.Sprintf("%.1f%%", share)})
+	}
+	rows = append(rows, []string{"Total", domain.FormatCost(cost.TotalCost, cost.Currency, format), ""})
+
+	costTable := table.New().
+		Border(lipgloss.NormalBorder()).
+		Headers("Model", "Cost", "Share").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Padding(0, 1)
+			if col > 0 {
+				style = style.Align(lipgloss.Right)
+			}
+			return style
+		})
+
+	lines := []string{costTable.String()}
+	if len(cost.Contributors) > 0 {
+		lines = append(lines, "", t.renderContributors(cost, format))
+	}
+	if tokens := cost.Tokens; tokens.Total() > 0 {
+		lines = append(lines, "", fmt.Sprintf("Tokens: %s in · %s out",
+			domain.FormatNumber(float64(tokens.Input), 0, format),
+			domain.FormatNumber(float64(tokens.Output), 0, format)))
+	}
+	if !data.LastUpdated.IsZero() {
+		lines = append(lines, "", "Updated "+data.LastUpdated.Format("15:04:05"))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	return lipgloss.Place(data.Config.Size.Width, data.Config.Size.Height, lipgloss.Center, lipgloss.Center, content), nil
+}
+
+// renderContributors renders the cost of each source when the cost adds up several sources
+func (t *TablePlugin) renderContributors(cost *domain.CostData, format domain.NumberFormat) string {
+	rows := make([][]string, 0, len(cost.Contributors))
+	for _, contribution := range cost.Contributors {
+		if contribution.Error != "" {
+			rows = append(rows, []string{contribution.Label, "unavailable", ""})
+			continue
+		}
+
+		share := 0.0
+		if cost.TotalCost > 0 {
+			share = contribution.Cost / cost.TotalCost * 100
+		}
+		rows = append(rows, []string{contribution.Label, domain.FormatCost(contribution.Cost, cost.Currency, format), fmt.Sprintf("%.1f%%", share)})
+	}
+
+	return table.New().
+		Border(lipgloss.NormalBorder()).
+		Headers("Source", "Cost", "Share").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Padding(0, 1)
+			if col > 0 {
+				style = style.Align(lipgloss.Right)
+			}
+			return style
+		}).
+		String()
+}
+
+// GetCapabilities returns the display capabilities
+func (t *TablePlugin) GetCapabilities() interfaces.DisplayCapabilities {
+	return interfaces.DisplayCapabilities{
+		MaxWidth:        400,
+		MaxHeight:       200,
+		SupportsColor:   false,
+		SupportsUnicode: true,
+		ShowsBreakdown:  true,
+	}
+}
+
+// ValidateDisplayConfig validates the display configuration
+func (t *TablePlugin) ValidateDisplayConfig(config *domain.DisplayConfig) error {
+	if config == nil {
+		return fmt.Errorf("display config cannot be nil")
+	}
+
+	capabilities := t.GetCapabilities()
+
+	// Check dimensions
+	if config.Size.Width > capabilities.MaxWidth {
+		return fmt.Errorf("width %d exceeds maximum %d", config.Size.Width, capabilities.MaxWidth)
+	}
+	if config.Size.Height > capabilities.MaxHeight {
+		return fmt.Errorf("height %d exceeds maximum %d", config.Size.Height, capabilities.MaxHeight)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Discover returns a plugin declaration for every executable file in the directory
+// and every subdirectory with a plugin manifest, sorted by file name. Hidden files
+// are skipped and a missing directory has no plugins. Invalid manifests are
+// reported in the error while the other plugins are still returned.
+func Discover(dir string) ([]domain.ExternalPluginConfig, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var plugins []domain.ExternalPluginConfig
+	var manifestErrs []error
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path) // Follows symlinks
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			manifestPath := filepath.Join(path, domain.PluginManifestFile)
+			if _, err := os.Stat(manifestPath); err != nil {
+				continue
+			}
+			manifest, err := LoadManifest(manifestPath)
+			if err != nil {
+				manifestErrs = append(manifestErrs, err)
+				continue
+			}
+			plugins = append(plugins, manifestSpec(manifest, path))
+			continue
+		}
+
+		if info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0 {
+			plugins = append(plugins, domain.ExternalPluginConfig{Command: path})
+		}
+	}
+
+	return plugins, errors.Join(manifestErrs...)
+}
+
+// LoadManifest reads and validates a plugin manifest
+func LoadManifest(path string) (*domain.PluginManifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin manifest '%s': %w", path, err)
+	}
+
+	var manifest domain.PluginManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin manifest '%s': %w", path, err)
+	}
+
+	switch {
+	case manifest.Name == "":
+		return nil, fmt.Errorf("plugin manifest '%s': no name", path)
+	case !manifest.Kind.IsValid():
+		return nil, fmt.Errorf("plugin manifest '%s': invalid kind: %s", path, manifest.Kind)
+	case manifest.Command == "":
+		return nil, fmt.Errorf("plugin manifest '%s': no command", path)
+	case manifest.Timeout < 0:
+		return nil, fmt.Errorf("plugin manifest '%s': timeout must not be negative", path)
+	}
+
+	return &manifest, nil
+}
+
+// manifestSpec declares the plugin described by a manifest in the given directory.
+// Commands containing a path separator are relative to that directory, others are looked up in PATH.
+func manifestSpec(manifest *domain.PluginManifest, dir string) domain.ExternalPluginConfig {
+	command := manifest.Command
+	if !filepath.IsAbs(command) && strings.ContainsRune(command, filepath.Separator) {
+		command = filepath.Join(dir, command)
+	}
+
+	return domain.ExternalPluginConfig{
+		Name:         manifest.Name,
+		Kind:         manifest.Kind,
+		Command:      command,
+		Args:         manifest.Args,
+		Timeout:      manifest.Timeout,
+		ConfigSchema: manifest.ConfigSchema,
+		Manifest:     manifest,
+	}
+}
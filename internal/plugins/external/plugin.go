@@ -0,0 +1,326 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// stopTimeout is how long a plugin may take to exit after shutdown
+const stopTimeout = 2 * time.Second
+
+// Plugin is the part of an external plugin proxy shared by all kinds.
+// The plugin process is started again on the next call if it crashes.
+type Plugin struct {
+	spec    domain.ExternalPluginConfig
+	info    handshakeResult
+	timeout time.Duration
+
+	mu      sync.Mutex
+	proc    *process
+	enabled bool
+	config  map[string]interface{}
+}
+
+// Load starts an external plugin, agrees on a protocol version and returns a proxy
+// implementing DataSourcePlugin, DisplayPlugin or AnimationPlugin depending on its kind
+func Load(ctx context.Context, spec domain.ExternalPluginConfig) (interfaces.Plugin, error) {
+	timeout := spec.Timeout
+	if timeout <= 0 {
+		timeout = domain.DefaultExternalPluginTimeout
+	}
+
+	base := &Plugin{spec: spec, timeout: timeout}
+	proc, info, err := base.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base.proc = proc
+	base.info = info
+	if spec.Name != "" {
+		base.info.Name = spec.Name
+	}
+
+	switch info.Kind {
+	case domain.PluginKindDataSource:
+		return &DataSource{base}, nil
+	case domain.PluginKindDisplay:
+		return &Display{base}, nil
+	default:
+		return &Animation{base}, nil
+	}
+}
+
+// start runs the plugin executable and performs the handshake
+func (p *Plugin) start(ctx context.Context) (*process, handshakeResult, error) {
+	var info handshakeResult
+
+	proc, err := startProcess(p.spec.Command, p.spec.Args)
+	if err != nil {
+		return nil, info, err
+	}
+
+	params := handshakeParams{ProtocolVersions: ProtocolVersions, Host: "ccugorg"}
+	if err := proc.call(ctx, methodHandshake, params, &info, p.timeout); err != nil {
+		proc.kill()
+		return nil, info, fmt.Errorf("handshake with '%s' failed: %w", p.spec.Command, err)
+	}
+
+	if err := p.checkHandshake(info); err != nil {
+		proc.stop(stopTimeout)
+		return nil, info, err
+	}
+
+	return proc, info, nil
+}
+
+// checkHandshake verifies the plugin speaks a supported protocol and is of the expected kind
+func (p *Plugin) checkHandshake(info handshakeResult) error {
+	if !slices.Contains(ProtocolVersions, info.ProtocolVersion) {
+		return fmt.Errorf("plugin '%s' uses unsupported protocol version %d", p.spec.Command, info.ProtocolVersion)
+	}
+	if info.Name == "" && p.spec.Name == "" {
+		return fmt.Errorf("plugin '%s' did not report a name", p.spec.Command)
+	}
+	if !info.Kind.IsValid() {
+		return fmt.Errorf("plugin '%s' reported invalid kind: %s", p.spec.Command, info.Kind)
+	}
+	if p.spec.Kind != "" && info.Kind != p.spec.Kind {
+		return fmt.Errorf("plugin '%s' is a %s plugin, expected %s", p.spec.Command, info.Kind, p.spec.Kind)
+	}
+	if p.info.Kind != "" && info.Kind != p.info.Kind {
+		return fmt.Errorf("plugin '%s' changed kind from %s to %s", p.spec.Command, p.info.Kind, info.Kind)
+	}
+	return nil
+}
+
+// running returns the plugin process, starting it again after a crash
+func (p *Plugin) running(ctx context.Context) (*process, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.proc != nil && !p.proc.exited() {
+		return p.proc, nil
+	}
+
+	proc, _, err := p.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.enabled {
+		if err := proc.call(ctx, methodInitialize, initializeParams{Config: p.config}, nil, p.timeout); err != nil {
+			proc.kill()
+			return nil, fmt.Errorf("failed to initialize restarted plugin '%s': %w", p.Name(), err)
+		}
+	}
+
+	p.proc = proc
+	return proc, nil
+}
+
+// call sends a request to the plugin process
+func (p *Plugin) call(ctx context.Context, method string, params, result interface{}) error {
+	proc, err := p.running(ctx)
+	if err != nil {
+		return err
+	}
+	return proc.call(ctx, method, params, result, p.timeout)
+}
+
+// Name returns the plugin name
+func (p *Plugin) Name() string {
+	return p.info.Name
+}
+
+// Version returns the plugin version
+func (p *Plugin) Version() string {
+	return p.info.Version
+}
+
+// Description returns the plugin description
+func (p *Plugin) Description() string {
+	return p.info.Description
+}
+
+// Kind returns what the plugin provides
+func (p *Plugin) Kind() domain.PluginKind {
+	return p.info.Kind
+}
+
+// ConfigSchema returns the settings the plugin accepts, from its declaration or else the handshake
+func (p *Plugin) ConfigSchema() []domain.ConfigOption {
+	if len(p.spec.ConfigSchema) > 0 {
+		return slices.Clone(p.spec.ConfigSchema)
+	}
+	return slices.Clone(p.info.ConfigSchema)
+}
+
+// IsEnabled returns whether the plugin is enabled
+func (p *Plugin) IsEnabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enabled
+}
+
+// Initialize sends the configured settings, overridden by the given config, to the plugin
+func (p *Plugin) Initialize(config map[string]interface{}) error {
+	merged := make(map[string]interface{}, len(p.spec.Config)+len(config))
+	maps.Copy(merged, p.spec.Config)
+	maps.Copy(merged, config)
+
+	if err := p.call(context.Background(), methodInitialize, initializeParams{Config: merged}, nil); err != nil {
+		return fmt.Errorf("failed to initialize plugin '%s': %w", p.Name(), err)
+	}
+
+	p.mu.Lock()
+	p.config = merged
+	p.enabled = true
+	p.mu.Unlock()
+	return nil
+}
+
+// Shutdown asks the plugin to shut down and stops its process
+func (p *Plugin) Shutdown() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.enabled = false
+	if p.proc == nil || p.proc.exited() {
+		return nil
+	}
+
+	err := p.proc.call(context.Background(), methodShutdown, nil, nil, p.timeout)
+	p.proc.stop(stopTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to shut down plugin '%s': %w", p.Name(), err)
+	}
+	return nil
+}
+
+// HealthCheck asks the plugin whether it can serve requests, restarting it if it crashed.
+// Plugins that do not implement the health method are healthy while they answer.
+func (p *Plugin) HealthCheck(ctx context.Context) error {
+	err := p.call(ctx, methodHealth, nil, nil)
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == codeMethodNotFound {
+		return nil
+	}
+	return err
+}
+
+// checkEnabled returns an error unless the plugin is enabled
+func (p *Plugin) checkEnabled() error {
+	if !p.IsEnabled() {
+		return domain.ErrPluginNotEnabled
+	}
+	return nil
+}
+
+// DataSource proxies an external data source plugin
+type DataSource struct {
+	*Plugin
+}
+
+// FetchCostData fetches cost data from the plugin
+func (d *DataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	if err := d.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	var data domain.CostData
+	if err := d.call(ctx, methodFetchCostData, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetLastUpdated returns when the plugin's data was last updated
+func (d *DataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	if err := d.checkEnabled(); err != nil {
+		return time.Time{}, err
+	}
+
+	var result lastUpdatedResult
+	if err := d.call(ctx, methodLastUpdated, nil, &result); err != nil {
+		return time.Time{}, err
+	}
+
+	updated, err := time.Parse(time.RFC3339, result.Time)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid last updated time: %w", err)
+	}
+	return updated, nil
+}
+
+// SupportsRealtime returns whether the plugin reported realtime support in the handshake
+func (d *DataSource) SupportsRealtime() bool {
+	return d.info.Realtime
+}
+
+// Display proxies an external display plugin
+type Display struct {
+	*Plugin
+}
+
+// Render renders the display data with the plugin
+func (d *Display) Render(ctx context.Context, data *domain.DisplayData) (string, error) {
+	if err := d.checkEnabled(); err != nil {
+		return "", err
+	}
+
+	var result renderResult
+	if err := d.call(ctx, methodRender, renderParams{Data: data}, &result); err != nil {
+		return "", err
+	}
+	return result.Output, nil
+}
+
+// GetCapabilities returns the capabilities reported in the handshake
+func (d *Display) GetCapabilities() interfaces.DisplayCapabilities {
+	if d.info.Capabilities == nil {
+		return interfaces.DisplayCapabilities{}
+	}
+	return *d.info.Capabilities
+}
+
+// ValidateDisplayConfig asks the plugin to validate the display configuration
+func (d *Display) ValidateDisplayConfig(config *domain.DisplayConfig) error {
+	return d.call(context.Background(), methodValidateDisplayConfig, displayConfigParams{Config: config}, nil)
+}
+
+// Animation proxies an external animation plugin
+type Animation struct {
+	*Plugin
+}
+
+// GenerateFrame generates an animation frame with the plugin
+func (a *Animation) GenerateFrame(ctx context.Context, text string, frameNumber int, config *domain.AnimationConfig) (*domain.AnimationFrame, error) {
+	if err := a.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	var frame domain.AnimationFrame
+	params := generateFrameParams{Text: text, FrameNumber: frameNumber, Config: config}
+	if err := a.call(ctx, methodGenerateFrame, params, &frame); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// GetSupportedPatterns returns the patterns reported in the handshake
+func (a *Animation) GetSupportedPatterns() []domain.AnimationPattern {
+	return slices.Clone(a.info.Patterns)
+}
+
+// ValidateAnimationConfig asks the plugin to validate the animation configuration
+func (a *Animation) ValidateAnimationConfig(config *domain.AnimationConfig) error {
+	return a.call(context.Background(), methodValidateAnimationConfig, animationConfigParams{Config: config}, nil)
+}
@@ -0,0 +1,236 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stderrLimit is how much of the end of a plugin's stderr is kept for error messages
+const stderrLimit = 2048
+
+// errProcessExited is returned for calls to a plugin process that is no longer running
+var errProcessExited = errors.New("plugin process exited")
+
+// process is a running plugin executable and the calls waiting for its responses
+type process struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr *tailBuffer
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan response
+	done    chan struct{}
+	exitErr error
+}
+
+// startProcess starts a plugin executable and begins reading its responses
+func startProcess(command string, args []string) (*process, error) {
+	cmd := exec.Command(command, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tailBuffer{limit: stderrLimit}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin '%s': %w", command, err)
+	}
+
+	p := &process{
+		cmd:     cmd,
+		stdin:   stdin,
+		stderr:  stderr,
+		pending: make(map[int64]chan response),
+		done:    make(chan struct{}),
+	}
+	go p.readResponses(stdout)
+	return p, nil
+}
+
+// readResponses delivers responses to the waiting calls until the plugin exits
+func (p *process) readResponses(stdout io.Reader) {
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var resp response
+			if json.Unmarshal(line, &resp) == nil {
+				p.mu.Lock()
+				if waiting, exists := p.pending[resp.ID]; exists {
+					delete(p.pending, resp.ID)
+					waiting <- resp
+				}
+				p.mu.Unlock()
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	waitErr := p.cmd.Wait()
+	p.mu.Lock()
+	p.exitErr = errProcessExited
+	if waitErr != nil {
+		p.exitErr = fmt.Errorf("%w: %v", errProcessExited, waitErr)
+	}
+	if tail := strings.TrimSpace(p.stderr.String()); tail != "" {
+		p.exitErr = fmt.Errorf("%w: %s", p.exitErr, tail)
+	}
+	p.pending = nil
+	p.mu.Unlock()
+	close(p.done)
+}
+
+// exited reports whether the plugin process is no longer running
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// call sends a request and decodes the result into result, which may be nil.
+// A plugin that does not read the request or answer it in time is killed, so a
+// hung plugin is restarted on the next call.
+func (p *process) call(ctx context.Context, method string, params, result interface{}, timeout time.Duration) error {
+	p.mu.Lock()
+	if p.pending == nil {
+		err := p.exitErr
+		p.mu.Unlock()
+		return err
+	}
+	p.nextID++
+	id := p.nextID
+	waiting := make(chan response, 1)
+	p.pending[id] = waiting
+	p.mu.Unlock()
+
+	message, err := json.Marshal(request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		p.forget(id)
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	timedOut := func() error {
+		p.forget(id)
+		p.kill()
+		return fmt.Errorf("plugin did not answer %s within %s", method, timeout)
+	}
+
+	// Write in the background, as a plugin that stops reading blocks the write once
+	// the pipe is full. Killing the plugin on timeout ends the write.
+	sent := make(chan error, 1)
+	go func() {
+		p.writeMu.Lock()
+		defer p.writeMu.Unlock()
+		_, err := p.stdin.Write(append(message, '\n'))
+		sent <- err
+	}()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			p.forget(id)
+			return fmt.Errorf("failed to send %s request: %w", method, err)
+		}
+	case <-timer.C:
+		return timedOut()
+	case <-ctx.Done():
+		p.forget(id)
+		return ctx.Err()
+	}
+
+	select {
+	case resp := <-waiting:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("invalid %s result: %w", method, err)
+			}
+		}
+		return nil
+	case <-p.done:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.exitErr
+	case <-timer.C:
+		return timedOut()
+	case <-ctx.Done():
+		p.forget(id)
+		return ctx.Err()
+	}
+}
+
+// forget stops waiting for the response to a request
+func (p *process) forget(id int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pending != nil {
+		delete(p.pending, id)
+	}
+}
+
+// stop closes the plugin's stdin and kills it unless it exits within the timeout
+func (p *process) stop(timeout time.Duration) {
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(timeout):
+		p.kill()
+		<-p.done
+	}
+}
+
+// kill terminates the plugin process
+func (p *process) kill() {
+	if p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+	}
+}
+
+// tailBuffer keeps the last bytes written to it
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+// Write implements io.Writer
+func (t *tailBuffer) Write(data []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.data = append(t.data, data...)
+	if len(t.data) > t.limit {
+		t.data = t.data[len(t.data)-t.limit:]
+	}
+	return len(data), nil
+}
+
+// String returns the kept bytes
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.data)
+}
@@ -0,0 +1,116 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+)
+
+// External plugins speak JSON-RPC 2.0 over stdin and stdout, one message per line.
+// The host sends requests and the plugin answers each with a response of the same id.
+
+// ProtocolVersions lists the protocol versions the host supports, newest first
+var ProtocolVersions = []int{1}
+
+// Methods of the plugin protocol
+const (
+	methodHandshake               = "handshake"
+	methodInitialize              = "initialize"
+	methodShutdown                = "shutdown"
+	methodHealth                  = "health"
+	methodFetchCostData           = "fetch_cost_data"
+	methodLastUpdated             = "last_updated"
+	methodRender                  = "render"
+	methodValidateDisplayConfig   = "validate_display_config"
+	methodGenerateFrame           = "generate_frame"
+	methodValidateAnimationConfig = "validate_animation_config"
+)
+
+// codeMethodNotFound is the JSON-RPC error for methods a plugin does not implement
+const codeMethodNotFound = -32601
+
+// request is a JSON-RPC request sent to the plugin
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response received from the plugin
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is an error reported by an external plugin
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("plugin error %d: %s", e.Code, e.Message)
+}
+
+// handshakeParams is sent first to agree on a protocol version
+type handshakeParams struct {
+	ProtocolVersions []int  `json:"protocol_versions"`
+	Host             string `json:"host"`
+}
+
+// handshakeResult describes the plugin. Kind-specific details that the plugin
+// interfaces return without errors are sent once here.
+type handshakeResult struct {
+	ProtocolVersion int                             `json:"protocol_version"`
+	Name            string                          `json:"name"`
+	Version         string                          `json:"version"`
+	Description     string                          `json:"description"`
+	Kind            domain.PluginKind               `json:"kind"`
+	Realtime        bool                            `json:"realtime,omitempty"`     // Data sources
+	Patterns        []domain.AnimationPattern       `json:"patterns,omitempty"`     // Animations
+	Capabilities    *interfaces.DisplayCapabilities `json:"capabilities,omitempty"` // Displays
+	ConfigSchema    []domain.ConfigOption           `json:"config_schema,omitempty"`
+}
+
+// initializeParams carries the plugin configuration
+type initializeParams struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// lastUpdatedResult is the result of last_updated
+type lastUpdatedResult struct {
+	Time string `json:"time"` // RFC 3339
+}
+
+// renderParams is sent to display plugins
+type renderParams struct {
+	Data *domain.DisplayData `json:"data"`
+}
+
+// renderResult is the output of a display plugin
+type renderResult struct {
+	Output string `json:"output"`
+}
+
+// displayConfigParams is sent to validate a display configuration
+type displayConfigParams struct {
+	Config *domain.DisplayConfig `json:"config"`
+}
+
+// generateFrameParams is sent to animation plugins
+type generateFrameParams struct {
+	Text        string                  `json:"text"`
+	FrameNumber int                     `json:"frame_number"`
+	Config      *domain.AnimationConfig `json:"config"`
+}
+
+// animationConfigParams is sent to validate an animation configuration
+type animationConfigParams struct {
+	Config *domain.AnimationConfig `json:"config"`
+}
@@ -0,0 +1,72 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAnimationController(t *testing.T) (*core.AnimationController, *core.ConfigManager) {
+	configManager := core.NewConfigManager()
+	registry := core.NewPluginRegistry(configManager)
+
+	plugin := animation.NewRainbowAnimationPlugin()
+	assert.NoError(t, registry.RegisterAnimation(plugin))
+	assert.NoError(t, registry.InitializePlugin(plugin))
+
+	return core.NewAnimationController(registry, configManager), configManager
+}
+
+func TestAnimationController_UpdateAnimationConfig(t *testing.T) {
+	controller, configManager := setupAnimationController(t)
+	ctx := context.Background()
+
+	config, err := controller.GetAnimationConfig(ctx)
+	assert.NoError(t, err)
+
+	config.Pattern = domain.PatternWave
+	config.Speed = 40 * time.Millisecond
+	err = controller.UpdateAnimationConfig(ctx, config)
+	assert.NoError(t, err)
+
+	// The update is visible through the config manager immediately
+	updated := configManager.GetAnimationConfig()
+	assert.Equal(t, domain.PatternWave, updated.Pattern)
+	assert.Equal(t, 40*time.Millisecond, updated.Speed)
+
+	// Invalid configurations are rejected by the animation plugin
+	config.Speed = 0
+	err = controller.UpdateAnimationConfig(ctx, config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "animation speed must be positive")
+	assert.Equal(t, 40*time.Millisecond, configManager.GetAnimationConfig().Speed)
+}
+
+func TestAnimationController_PauseResume(t *testing.T) {
+	controller, _ := setupAnimationController(t)
+	ctx := context.Background()
+
+	assert.False(t, controller.IsPaused())
+
+	assert.NoError(t, controller.StopAnimation(ctx))
+	assert.True(t, controller.IsPaused())
+
+	assert.NoError(t, controller.StartAnimation(ctx))
+	assert.False(t, controller.IsPaused())
+}
+
+func TestAnimationController_GenerateAnimationFrame(t *testing.T) {
+	controller, _ := setupAnimationController(t)
+
+	frame, err := controller.GenerateAnimationFrame(context.Background(), "$1.00", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "$1.00", frame.Text)
+	assert.Len(t, frame.Colors, 5)
+
+	assert.Len(t, controller.SupportedPatterns(), 4)
+}
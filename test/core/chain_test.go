@@ -0,0 +1,94 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginRegistry_FetchCostData_Chain(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "missing"
+	config.Plugins.DataSources = []string{"missing", "primary", "backup"}
+
+	registry := core.NewPluginRegistry(configManager)
+	primary := &stubDataSource{name: "primary"}
+	backup := &stubDataSource{name: "backup"}
+	assert.NoError(t, registry.RegisterDataSource(primary))
+	assert.NoError(t, registry.RegisterDataSource(backup))
+	assert.NoError(t, registry.InitializeAll())
+
+	// The first available data source serves the data
+	data, report, err := registry.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, data.TotalCost)
+	assert.Equal(t, domain.FetchReport{Source: "primary", Reason: "missing: not available"}, report)
+
+	// A failing data source falls through to the next one
+	primary.fetchErr = errors.New("ccusage timed out")
+	_, report, err = registry.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "backup", report.Source)
+	assert.Equal(t, "missing: not available; primary: ccusage timed out", report.Reason)
+	assert.True(t, report.IsFallback())
+	assert.Equal(t, report, registry.LastFetch())
+	assert.Equal(t, domain.PluginStateDegraded, registry.PluginState(primary))
+
+	// When every data source fails the errors are returned together
+	backup.fetchErr = errors.New("no data")
+	_, report, err = registry.FetchCostData(context.Background())
+	assert.ErrorContains(t, err, "all data sources failed")
+	assert.ErrorContains(t, err, "ccusage timed out")
+	assert.ErrorContains(t, err, "no data")
+	assert.Empty(t, report.Source)
+}
+
+func TestPluginRegistry_FetchCostData_FailedLast(t *testing.T) {
+	registry, primary, backup := newLifecycleRegistry(t)
+	assert.NoError(t, registry.InitializeAll())
+
+	for range core.FailureThreshold {
+		registry.RecordResult(primary, errors.New("timeout"))
+	}
+
+	// A failed data source is passed over while others work
+	_, report, err := registry.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FetchReport{Source: "backup", Reason: "primary: failed"}, report)
+
+	// It is still tried as a last resort, and recovers when it succeeds
+	backup.fetchErr = errors.New("no data")
+	_, report, err = registry.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FetchReport{Source: "primary", Reason: "backup: no data"}, report)
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(primary))
+}
+
+func TestPluginRegistry_FetchCostData_ConfiguredOnly(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "primary"
+
+	registry := core.NewPluginRegistry(configManager)
+	primary := &stubDataSource{name: "primary", fetchErr: errors.New("ccusage timed out")}
+	team := &stubDataSource{name: "shared-dir"}
+	assert.NoError(t, registry.RegisterDataSource(primary))
+	assert.NoError(t, registry.RegisterDataSource(team))
+	assert.NoError(t, registry.InitializeAll())
+
+	// A data source that is not configured never stands in for the configured one
+	_, report, err := registry.FetchCostData(context.Background())
+	assert.EqualError(t, err, "ccusage timed out")
+	assert.Empty(t, report.Source)
+
+	for range core.FailureThreshold {
+		registry.RecordResult(primary, errors.New("timeout"))
+	}
+	active, err := registry.GetActiveDataSource()
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", active.Name())
+}
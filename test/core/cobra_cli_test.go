@@ -156,6 +156,77 @@ func TestCobraCLI_BankruptcyFlag(t *testing.T) {
 	assert.True(t, flagConfig.Bankruptcy, "Bankruptcy flag should be set")
 }
 
+// TestCobraCLI_CurrencyFlag tests currency and config flags with cobra
+func TestCobraCLI_CurrencyFlag(t *testing.T) {
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--currency", "jpy", "--config", "/tmp/ccugorg.yaml"})
+	assert.NoError(t, err)
+	assert.Equal(t, "jpy", flagConfig.Currency)
+	assert.Equal(t, "/tmp/ccugorg.yaml", flagConfig.ConfigPath)
+
+	// Apply uppercases the currency code
+	configManager := core.NewConfigManager()
+	err = configManager.ApplyFlagsToConfig(flagConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "JPY", configManager.GetConfig().Currency.Display)
+
+	// Currency codes must have three letters
+	_, err = core.ParseCobraFlagsFromArgs([]string{"--currency", "YEN!"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid currency code")
+}
+
+// TestCobraCLI_ColorFlag tests color mode flag with cobra
+func TestCobraCLI_ColorFlag(t *testing.T) {
+	for _, mode := range []domain.ColorMode{domain.ColorModeAuto, domain.ColorModeAlways, domain.ColorModeNever} {
+		flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--color", string(mode)})
+		assert.NoError(t, err)
+		assert.Equal(t, mode, flagConfig.ColorMode)
+	}
+
+	_, err := core.ParseCobraFlagsFromArgs([]string{"--color", "sometimes"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid color mode")
+
+	// Flag overrides the configured mode
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--color=never"})
+	assert.NoError(t, err)
+	configManager := core.NewConfigManager()
+	assert.NoError(t, configManager.ApplyFlagsToConfig(flagConfig))
+	assert.Equal(t, domain.ColorModeNever, configManager.GetConfig().Display.ColorMode)
+	assert.NoError(t, configManager.ValidateConfig())
+}
+
+// TestCobraCLI_SaveSettingsFlag tests save-settings flag with cobra
+func TestCobraCLI_SaveSettingsFlag(t *testing.T) {
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--save-settings"})
+	assert.NoError(t, err)
+	assert.True(t, flagConfig.SaveSettings)
+
+	configManager := core.NewConfigManager()
+	assert.NoError(t, configManager.ApplyFlagsToConfig(flagConfig))
+	assert.True(t, configManager.GetConfig().App.SaveSettingsOnExit)
+}
+
+func TestCobraCLI_DisplayFlag(t *testing.T) {
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--display", "dashboard"})
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard", flagConfig.Display)
+
+	configManager := core.NewConfigManager()
+	assert.NoError(t, configManager.ApplyFlagsToConfig(flagConfig))
+	assert.Equal(t, "dashboard", configManager.GetConfig().Plugins.Display)
+}
+
+func TestCobraCLI_KioskFlag(t *testing.T) {
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--kiosk"})
+	assert.NoError(t, err)
+	assert.True(t, flagConfig.Kiosk)
+
+	configManager := core.NewConfigManager()
+	assert.NoError(t, configManager.ApplyFlagsToConfig(flagConfig))
+	assert.True(t, configManager.GetKioskConfig().Enabled)
+}
+
 // TestCobraCLI_UnsupportedFlags tests that unsupported flags are rejected
 func TestCobraCLI_UnsupportedFlags(t *testing.T) {
 	unsupportedFlags := []struct {
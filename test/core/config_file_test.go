@@ -0,0 +1,140 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `# ccugorg settings
+animation:
+  speed: 100ms # frame interval
+  pattern: rainbow
+currency:
+  display: EUR
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	err := core.UpdateConfigFile(path, map[string]interface{}{
+		"animation.speed":   "50ms",
+		"animation.theme":   "neon",
+		"display.color":     "never",
+		"currency.display":  "JPY",
+		"animation.enabled": false,
+	})
+	assert.NoError(t, err)
+
+	updated, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	text := string(updated)
+
+	// Comments and unrelated keys survive
+	assert.Contains(t, text, "# ccugorg settings")
+	assert.Contains(t, text, "speed: 50ms # frame interval")
+	assert.Contains(t, text, "pattern: rainbow")
+
+	// The result loads back with the new values
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(path))
+	config := cm.GetConfig()
+	assert.Equal(t, 50*time.Millisecond, config.Animation.Speed)
+	assert.Equal(t, "neon", config.Animation.Theme)
+	assert.False(t, config.Animation.Enabled)
+	assert.Equal(t, domain.ColorModeNever, config.Display.ColorMode)
+	assert.Equal(t, "JPY", config.Currency.Display)
+}
+
+func TestUpdateConfigFile_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	err := core.UpdateConfigFile(path, map[string]interface{}{"animation.pattern": "pulse"})
+	assert.NoError(t, err)
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(path))
+	assert.Equal(t, domain.PatternPulse, cm.GetConfig().Animation.Pattern)
+}
+
+func TestUpdateConfigFile_NotASection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("animation: fast\n"), 0o644))
+
+	err := core.UpdateConfigFile(path, map[string]interface{}{"animation.speed": "10ms"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a section")
+}
+
+func TestConfigManager_SaveAnimationSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("app:\n  log_level: debug\n"), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(path))
+	assert.NoError(t, cm.SetTheme("dracula"))
+	cm.GetConfig().Animation.Speed = 80 * time.Millisecond
+	cm.GetConfig().Animation.Pattern = domain.PatternGradient
+
+	assert.NoError(t, cm.SaveAnimationSettings())
+
+	reloaded := core.NewConfigManager()
+	assert.NoError(t, reloaded.LoadConfig(path))
+	config := reloaded.GetConfig()
+	assert.Equal(t, "debug", config.App.LogLevel)
+	assert.Equal(t, 80*time.Millisecond, config.Animation.Speed)
+	assert.Equal(t, domain.PatternGradient, config.Animation.Pattern)
+	assert.Equal(t, "dracula", config.Animation.Theme)
+	assert.Equal(t, "#FF5555", config.Animation.Colors[0])
+}
+
+func TestConfigManager_SetPluginEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("app:\n  log_level: debug\n"), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(path))
+	assert.True(t, cm.IsPluginEnabled("table"))
+
+	assert.NoError(t, cm.SetPluginEnabled("table", false))
+	assert.False(t, cm.IsPluginEnabled("table"))
+
+	reloaded := core.NewConfigManager()
+	assert.NoError(t, reloaded.LoadConfig(path))
+	assert.Equal(t, "debug", reloaded.GetConfig().App.LogLevel)
+	assert.Equal(t, []string{"table"}, reloaded.GetConfig().Plugins.Disabled)
+	assert.False(t, reloaded.IsPluginEnabled("table"))
+
+	assert.NoError(t, reloaded.SetPluginEnabled("table", true))
+	assert.True(t, reloaded.IsPluginEnabled("table"))
+
+	reloaded = core.NewConfigManager()
+	assert.NoError(t, reloaded.LoadConfig(path))
+	assert.Empty(t, reloaded.GetConfig().Plugins.Disabled)
+
+	// Selected plugins cannot be disabled
+	assert.ErrorContains(t, cm.SetPluginEnabled("ccusage-cli", false), "selected data source")
+	assert.ErrorContains(t, cm.SetPluginEnabled("rainbow-animation", false), "selected animation")
+	assert.ErrorContains(t, cm.SetPluginEnabled("rainbow-display", false), "selected display")
+}
+
+func TestConfigManager_DescribePlugin(t *testing.T) {
+	cm := core.NewConfigManager()
+	cm.GetConfig().Plugins.Disabled = []string{"table"}
+
+	info := cm.DescribePlugin(display.NewTablePlugin(), "built-in")
+	assert.Equal(t, domain.PluginInfo{
+		Name:        "table",
+		Kind:        domain.PluginKindDisplay,
+		Version:     "1.0.0",
+		Description: "Plain table display plugin",
+		Source:      "built-in",
+		Enabled:     false,
+	}, info)
+}
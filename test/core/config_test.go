@@ -1,6 +1,8 @@
 package core_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -33,9 +35,60 @@ func TestConfigManager_LoadConfig_Defaults(t *testing.T) {
 	assert.Len(t, config.Animation.Colors, 12)
 }
 
-// TestConfigManager_LoadConfig_FromFile removed since file loading is no longer supported
+func TestConfigManager_LoadConfig_FromFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+animation:
+  speed: 50ms
+  pattern: wave
+display:
+  number_format:
+    abbreviate: true
+currency:
+  display: JPY
+  rates_date: "2026-10-01"
+  rates:
+    JPY: 150.5
+`
+	err := os.WriteFile(configPath, []byte(content), 0o644)
+	assert.NoError(t, err)
+
+	cm := core.NewConfigManager()
+	err = cm.LoadConfig(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, configPath, cm.ConfigPath())
+
+	config := cm.GetConfig()
+	assert.Equal(t, 50*time.Millisecond, config.Animation.Speed)
+	assert.Equal(t, domain.PatternWave, config.Animation.Pattern)
+	assert.True(t, config.Display.NumberFormat.Abbreviate)
+	assert.Equal(t, ",", config.Display.NumberFormat.ThousandsSeparator) // Defaults are kept
+	assert.Equal(t, "JPY", config.Currency.Display)
+	assert.Equal(t, 150.5, config.Currency.Rates["JPY"])
+	assert.Len(t, config.Animation.Colors, 12)
+
+	assert.NoError(t, cm.ValidateConfig())
+}
 
-// TestConfigManager_LoadConfig_InvalidYAML removed since file loading is no longer supported
+func TestConfigManager_LoadConfig_MissingFile(t *testing.T) {
+	cm := core.NewConfigManager()
+
+	// An explicitly requested file must exist
+	err := cm.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read config file")
+}
+
+func TestConfigManager_LoadConfig_InvalidYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte("animation: [unclosed"), 0o644)
+	assert.NoError(t, err)
+
+	cm := core.NewConfigManager()
+	err = cm.LoadConfig(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse config file")
+}
 
 func TestConfigManager_GetDisplayConfig(t *testing.T) {
 	cm := core.NewConfigManager()
@@ -171,3 +224,388 @@ func TestConfigManager_ValidateConfig_InvalidAnimationSpeed(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "animation speed must be positive")
 }
+
+func TestConfigManager_ValidateConfig_Currency(t *testing.T) {
+	cm := core.NewConfigManager()
+	config := cm.GetConfig()
+
+	// Display currency without any rates cannot be converted
+	config.Currency.Display = "EUR"
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no exchange rates configured")
+
+	// Inline rates make it valid
+	config.Currency.Rates = map[string]float64{"EUR": 0.92}
+	assert.NoError(t, cm.ValidateConfig())
+
+	// Rates must be positive
+	config.Currency.Rates["EUR"] = 0
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be positive")
+
+	// Currency codes must have three letters
+	config.Currency.Display = "EURO"
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid display currency")
+}
+
+func TestConfigManager_ValidateConfig_Transition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `display:
+  transition:
+    duration: 1.5s
+    easing: ease-in-out
+    flash_digits: false
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(path))
+	assert.NoError(t, cm.ValidateConfig())
+
+	// Unset keys keep their defaults
+	transition := cm.GetTransitionConfig()
+	assert.Equal(t, 1500*time.Millisecond, transition.Duration)
+	assert.Equal(t, domain.EasingEaseInOut, transition.Easing)
+	assert.False(t, transition.FlashDigits)
+	assert.True(t, transition.DeltaBadge)
+	assert.Equal(t, 3*time.Second, transition.BadgeDuration)
+
+	config := cm.GetConfig()
+	config.Display.Transition.Easing = "bounce"
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid transition easing")
+
+	config.Display.Transition.Easing = domain.EasingLinear
+	config.Display.Transition.Duration = -time.Second
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "transition duration must not be negative")
+}
+
+func TestConfigManager_ValidateConfig_Milestones(t *testing.T) {
+	cm := core.NewConfigManager()
+	config := cm.GetConfig()
+
+	assert.Equal(t, 100.0, cm.GetMilestoneConfig().Every)
+	assert.NoError(t, cm.ValidateConfig())
+
+	config.Milestones.Amounts = []float64{50, 0}
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "milestone amount must be positive")
+
+	config.Milestones.Amounts = nil
+	config.Milestones.Every = -1
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "milestone interval must not be negative")
+
+	// The history file can be moved
+	config.App.HistoryFile = "/tmp/ccugorg-history.jsonl"
+	assert.Equal(t, "/tmp/ccugorg-history.jsonl", cm.HistoryPath())
+}
+
+func TestConfigManager_Dashboard(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+display:
+  dashboard:
+    budget: 150
+    layout:
+      - weight: 2
+        columns:
+          - pane: total
+            weight: 3
+          - pane: budget
+      - columns:
+          - pane: status
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+
+	dashboard := cm.GetDisplayConfig().Dashboard
+	assert.Equal(t, 150.0, dashboard.Budget)
+	assert.Len(t, dashboard.Layout, 2)
+	assert.Equal(t, domain.PaneTotal, dashboard.Layout[0].Columns[0].Pane)
+	assert.Equal(t, 3, dashboard.Layout[0].Columns[0].Weight)
+	assert.Equal(t, domain.PaneStatus, dashboard.Layout[1].Columns[0].Pane)
+
+	config := cm.GetConfig()
+	config.Display.Dashboard.Layout[1].Columns[0].Pane = "clock"
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pane: clock")
+
+	config.Display.Dashboard.Layout[1].Columns[0].Pane = domain.PaneTotal
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "pane total appears more than once")
+
+	config.Display.Dashboard = domain.DefaultDashboardConfig()
+	config.Display.Dashboard.Budget = -1
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "dashboard budget must not be negative")
+}
+
+func TestConfigManager_Displays(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+plugins:
+  displays: [dashboard, rainbow-display, table]
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	// The first listed display is shown at startup
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+	assert.Equal(t, "dashboard", cm.GetConfig().Plugins.Display)
+	assert.Equal(t, []string{"dashboard", "rainbow-display", "table"}, cm.DisplayNames())
+
+	// A display picked outside the list is added in front
+	cm.GetConfig().Plugins.Display = "custom"
+	assert.Equal(t, []string{"custom", "dashboard", "rainbow-display", "table"}, cm.DisplayNames())
+
+	// Without a list the configured display is the only one
+	cm = core.NewConfigManager()
+	assert.Equal(t, []string{"rainbow-display"}, cm.DisplayNames())
+
+	cm.GetConfig().Plugins.Displays = []string{"table", "table"}
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "display plugin table is listed more than once")
+}
+
+func TestConfigManager_DataSources(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+plugins:
+  datasource: [native-jsonl, ccusage-cli]
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	// The first listed data source is tried first
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+	assert.Equal(t, "native-jsonl", cm.GetConfig().Plugins.DataSource)
+	assert.Equal(t, []string{"native-jsonl", "ccusage-cli"}, cm.DataSourceNames())
+
+	// A single name is still accepted
+	assert.NoError(t, os.WriteFile(configPath, []byte("plugins:\n  datasource: bankruptcy-datasource\n"), 0o644))
+	cm = core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.Equal(t, []string{"bankruptcy-datasource"}, cm.DataSourceNames())
+
+	cm.GetConfig().Plugins.DataSources = []string{"ccusage-cli", "ccusage-cli"}
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "data source plugin ccusage-cli is listed more than once")
+}
+
+func TestConfigManager_Composite(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+plugins:
+  datasource: composite
+  composite:
+    timeout: 20s
+    sources:
+      - datasource: ccusage-cli
+      - label: work
+        datasource: ccusage-cli
+        config:
+          config_dir: /home/me/.claude-work
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+	composite := cm.GetConfig().Plugins.Composite
+	assert.Equal(t, 20*time.Second, composite.Timeout)
+	assert.Len(t, composite.Sources, 2)
+	assert.Equal(t, "ccusage-cli", composite.Sources[0].Name())
+	assert.Equal(t, "work", composite.Sources[1].Name())
+	assert.Equal(t, "/home/me/.claude-work", composite.Sources[1].Config["config_dir"])
+
+	composite.Sources[1].Label = ""
+	cm.GetConfig().Plugins.Composite = composite
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "composite source ccusage-cli is listed more than once")
+
+	cm.GetConfig().Plugins.Composite.Sources = []domain.CompositeSource{{DataSource: "composite"}}
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot include itself")
+}
+
+func TestConfigManager_Team(t *testing.T) {
+	cm := core.NewConfigManager()
+	team := cm.GetTeamConfig()
+	assert.Empty(t, team.Dir)
+	assert.Equal(t, "ccusage-cli", team.DataSource)
+	assert.Equal(t, 24*time.Hour, team.MaxAge)
+
+	cm.GetConfig().Team.Share = true
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "team sharing needs a shared directory")
+
+	cm.GetConfig().Team.Dir = "/mnt/team"
+	assert.NoError(t, cm.ValidateConfig())
+
+	cm.GetConfig().Team.Member = "../alice"
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must not contain path separators")
+
+	cm.GetConfig().Team.Member = "alice"
+	cm.GetConfig().Team.DataSource = "shared-dir"
+	err = cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must come from a local data source")
+}
+
+func TestConfigManager_CachePath(t *testing.T) {
+	cm := core.NewConfigManager()
+	assert.Equal(t, core.DefaultCachePath(), cm.CachePath())
+
+	cm.GetConfig().App.CacheFile = "/tmp/ccugorg-cost.json"
+	assert.Equal(t, "/tmp/ccugorg-cost.json", cm.CachePath())
+
+	cm.GetConfig().DataSource.CacheTime = -time.Second
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cache time must not be negative")
+}
+
+func TestConfigManager_PluginConfig(t *testing.T) {
+	cm := core.NewConfigManager()
+	cm.GetConfig().DataSource.CcusagePath = "/opt/bin/ccusage"
+	cm.GetConfig().DataSource.Timeout = time.Minute
+
+	assert.Equal(t, map[string]interface{}{
+		"ccusage_path": "/opt/bin/ccusage",
+		"timeout":      "1m0s",
+		"cache_time":   "10s",
+	}, cm.PluginConfig("ccusage-cli"))
+	assert.Empty(t, cm.PluginConfig("rainbow-display"))
+
+	cm.GetConfig().DataSource.Timeout = 0
+	err := cm.ValidateConfig()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "data source timeout must be positive")
+}
+
+func TestConfigManager_Kiosk(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+kiosk:
+  enabled: true
+  rotate_interval: 1m
+  quiet_hours:
+    start: "22:00"
+    end: "07:00"
+    brightness: 0.5
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+
+	kiosk := cm.GetKioskConfig()
+	assert.True(t, kiosk.Enabled)
+	assert.Equal(t, time.Minute, kiosk.RotateInterval)
+	assert.Equal(t, 30*time.Second, kiosk.RetryInterval)
+	assert.Equal(t, domain.QuietHours{Start: "22:00", End: "07:00", Brightness: 0.5}, kiosk.QuietHours)
+
+	tests := []struct {
+		name   string
+		modify func(*domain.KioskConfig)
+		errMsg string
+	}{
+		{"Negative drift interval", func(k *domain.KioskConfig) { k.DriftInterval = -time.Second }, "kiosk intervals must not be negative"},
+		{"Negative drift range", func(k *domain.KioskConfig) { k.DriftRange = -1 }, "kiosk drift range must not be negative"},
+		{"No retry interval", func(k *domain.KioskConfig) { k.RetryInterval = 0 }, "kiosk retry interval must be positive"},
+		{"Missing end", func(k *domain.KioskConfig) { k.QuietHours.End = "" }, "quiet hours need both a start and an end"},
+		{"Invalid start", func(k *domain.KioskConfig) { k.QuietHours.Start = "10pm" }, "invalid quiet hours"},
+		{"Brightness too high", func(k *domain.KioskConfig) { k.QuietHours.Brightness = 1.5 }, "quiet hours brightness must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := core.NewConfigManager()
+			assert.NoError(t, cm.LoadConfig(configPath))
+			tt.modify(&cm.GetConfig().Kiosk)
+			err := cm.ValidateConfig()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestConfigManager_ExternalPlugins(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+plugins:
+  directory: /opt/ccugorg/plugins
+  external:
+    - name: team-costs
+      kind: datasource
+      command: /usr/local/bin/team-costs
+      args: ["--team", "platform"]
+      timeout: 5s
+      config:
+        endpoint: https://costs.example.com
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.NoError(t, cm.ValidateConfig())
+	assert.Equal(t, "/opt/ccugorg/plugins", cm.PluginDirectory())
+	assert.Equal(t, []domain.ExternalPluginConfig{{
+		Name:    "team-costs",
+		Kind:    domain.PluginKindDataSource,
+		Command: "/usr/local/bin/team-costs",
+		Args:    []string{"--team", "platform"},
+		Timeout: 5 * time.Second,
+		Config:  map[string]interface{}{"endpoint": "https://costs.example.com"},
+	}}, cm.GetConfig().Plugins.External)
+
+	tests := []struct {
+		name   string
+		plugin domain.ExternalPluginConfig
+		errMsg string
+	}{
+		{"No command", domain.ExternalPluginConfig{Name: "empty"}, "external plugin 1: no command"},
+		{"Invalid kind", domain.ExternalPluginConfig{Command: "plugin", Kind: "theme"}, "external plugin 1: invalid kind: theme"},
+		{"Negative timeout", domain.ExternalPluginConfig{Command: "plugin", Timeout: -time.Second}, "external plugin 1: timeout must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := core.NewConfigManager()
+			cm.GetConfig().Plugins.External = []domain.ExternalPluginConfig{tt.plugin}
+			err := cm.ValidateConfig()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	assert.Equal(t, 30*time.Second, cm.GetConfig().Plugins.HealthCheckInterval)
+	cm.GetConfig().Plugins.HealthCheckInterval = -time.Second
+	assert.ErrorContains(t, cm.ValidateConfig(), "plugin health check interval must not be negative")
+}
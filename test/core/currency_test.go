@@ -0,0 +1,63 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRateProvider returns fixed rates or an error
+type stubRateProvider struct {
+	rates *domain.ExchangeRates
+	err   error
+	calls int
+}
+
+func (s *stubRateProvider) Name() string {
+	return "stub"
+}
+
+func (s *stubRateProvider) GetRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	s.calls++
+	return s.rates, s.err
+}
+
+func TestCurrencyConverter_Convert(t *testing.T) {
+	provider := &stubRateProvider{
+		rates: &domain.ExchangeRates{
+			Base:  "USD",
+			Date:  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			Rates: map[string]float64{"EUR": 0.9},
+		},
+	}
+	converter := core.NewCurrencyConverter(provider, "eur")
+	assert.Equal(t, "EUR", converter.TargetCurrency())
+
+	costData := &domain.CostData{TotalCost: 100, Currency: "USD"}
+	converted, err := converter.Convert(context.Background(), costData)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", converted.Currency)
+	assert.InDelta(t, 90.0, converted.TotalCost, 1e-9)
+	assert.Equal(t, 1, provider.calls)
+
+	// Data already in the target currency is passed through without loading rates
+	same, err := converter.Convert(context.Background(), converted)
+	assert.NoError(t, err)
+	assert.Same(t, converted, same)
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestCurrencyConverter_Convert_ProviderError(t *testing.T) {
+	provider := &stubRateProvider{err: errors.New("rates unavailable")}
+	converter := core.NewCurrencyConverter(provider, "JPY")
+
+	_, err := converter.Convert(context.Background(), &domain.CostData{TotalCost: 1, Currency: "USD"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load exchange rates from stub provider")
+	assert.Contains(t, err.Error(), "rates unavailable")
+}
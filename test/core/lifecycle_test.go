@@ -0,0 +1,179 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDataSource is a data source whose initialization and health can be controlled
+type stubDataSource struct {
+	name      string
+	initErr   error
+	healthErr error
+	fetchErr  error
+	enabled   bool
+}
+
+func (s *stubDataSource) Name() string        { return s.name }
+func (s *stubDataSource) Version() string     { return "1.0.0" }
+func (s *stubDataSource) Description() string { return "Stub data source" }
+func (s *stubDataSource) IsEnabled() bool     { return s.enabled }
+func (s *stubDataSource) SupportsRealtime() bool {
+	return false
+}
+
+func (s *stubDataSource) Initialize(config map[string]interface{}) error {
+	if s.initErr != nil {
+		return s.initErr
+	}
+	s.enabled = true
+	return nil
+}
+
+func (s *stubDataSource) Shutdown() error {
+	s.enabled = false
+	return nil
+}
+
+func (s *stubDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	if s.fetchErr != nil {
+		return nil, s.fetchErr
+	}
+	return &domain.CostData{TotalCost: 1, Currency: "USD"}, nil
+}
+
+func (s *stubDataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+func (s *stubDataSource) HealthCheck(ctx context.Context) error {
+	return s.healthErr
+}
+
+// newLifecycleRegistry creates a registry with a primary and a backup data source
+func newLifecycleRegistry(t *testing.T) (*core.PluginRegistry, *stubDataSource, *stubDataSource) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "primary"
+	configManager.GetConfig().Plugins.DataSources = []string{"primary", "backup"}
+
+	registry := core.NewPluginRegistry(configManager)
+	primary := &stubDataSource{name: "primary"}
+	backup := &stubDataSource{name: "backup"}
+	assert.NoError(t, registry.RegisterDataSource(primary))
+	assert.NoError(t, registry.RegisterDataSource(backup))
+	return registry, primary, backup
+}
+
+func TestPluginRegistry_Lifecycle(t *testing.T) {
+	registry, primary, backup := newLifecycleRegistry(t)
+	assert.Equal(t, domain.PluginStateRegistered, registry.PluginState(primary))
+
+	assert.NoError(t, registry.InitializeAll())
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(primary))
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(backup))
+
+	// Failures degrade the plugin until the threshold fails it
+	for i := 1; i < core.FailureThreshold; i++ {
+		registry.RecordResult(primary, errors.New("timeout"))
+		assert.Equal(t, domain.PluginStateDegraded, registry.PluginState(primary))
+	}
+	active, err := registry.GetActiveDataSource()
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", active.Name())
+
+	registry.RecordResult(primary, errors.New("timeout"))
+	assert.Equal(t, domain.PluginStateFailed, registry.PluginState(primary))
+
+	// The backup takes over while the primary is failed
+	active, err = registry.GetActiveDataSource()
+	assert.NoError(t, err)
+	assert.Equal(t, "backup", active.Name())
+
+	statuses := registry.PluginStatuses()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "primary", statuses[0].Name)
+	assert.Equal(t, core.FailureThreshold, statuses[0].Failures)
+	assert.Equal(t, "timeout", statuses[0].LastError)
+
+	// A success makes the primary ready again
+	registry.RecordResult(primary, nil)
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(primary))
+	active, _ = registry.GetActiveDataSource()
+	assert.Equal(t, "primary", active.Name())
+
+	assert.NoError(t, registry.ShutdownAll())
+	assert.Equal(t, domain.PluginStateStopped, registry.PluginState(primary))
+	registry.RecordResult(primary, nil)
+	assert.Equal(t, domain.PluginStateStopped, registry.PluginState(primary))
+}
+
+func TestPluginRegistry_InitializeAll_ContinuesAfterFailure(t *testing.T) {
+	registry, primary, backup := newLifecycleRegistry(t)
+	primary.initErr = errors.New("ccusage not installed")
+
+	err := registry.InitializeAll()
+	assert.ErrorContains(t, err, "failed to initialize plugin 'primary': ccusage not installed")
+	assert.Equal(t, domain.PluginStateFailed, registry.PluginState(primary))
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(backup))
+
+	active, err := registry.GetActiveDataSource()
+	assert.NoError(t, err)
+	assert.Equal(t, "backup", active.Name())
+}
+
+func TestPluginRegistry_CheckHealth(t *testing.T) {
+	registry, primary, backup := newLifecycleRegistry(t)
+	primary.initErr = errors.New("not ready yet")
+	_ = registry.InitializeAll()
+
+	// Unhealthy plugins degrade and then fail
+	backup.healthErr = errors.New("unreachable")
+	registry.CheckHealth(context.Background())
+	assert.Equal(t, domain.PluginStateDegraded, registry.PluginState(backup))
+	assert.Equal(t, domain.PluginStateFailed, registry.PluginState(primary))
+
+	// Nothing is initialized once the checks are stopped for shutdown
+	primary.initErr = nil
+	backup.healthErr = nil
+	stopped, cancel := context.WithCancel(context.Background())
+	cancel()
+	registry.CheckHealth(stopped)
+	assert.Equal(t, domain.PluginStateFailed, registry.PluginState(primary))
+	assert.False(t, primary.IsEnabled())
+
+	// A failed plugin that never initialized is initialized again once healthy
+	registry.CheckHealth(context.Background())
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(primary))
+	assert.True(t, primary.IsEnabled())
+	assert.Equal(t, domain.PluginStateReady, registry.PluginState(backup))
+}
+
+func TestPluginRegistry_DisplayFallback(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.Display = "rainbow-display"
+	config.Plugins.Displays = []string{"rainbow-display", "table"}
+
+	registry := core.NewPluginRegistry(configManager)
+	rainbow := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.Register(rainbow))
+	assert.NoError(t, registry.Register(display.NewDashboardPlugin()))
+	assert.NoError(t, registry.Register(display.NewTablePlugin()))
+	assert.NoError(t, registry.InitializeAll())
+
+	for range core.FailureThreshold {
+		registry.RecordResult(rainbow, errors.New("render failed"))
+	}
+
+	// The configured displays are preferred over other registered ones
+	active, err := registry.GetActiveDisplay()
+	assert.NoError(t, err)
+	assert.Equal(t, "table", active.Name())
+}
@@ -1,9 +1,11 @@
 package core_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
@@ -268,6 +270,38 @@ func TestPluginRegistry_GetActivePlugins(t *testing.T) {
 	assert.Equal(t, animPlugin, activeAnim)
 }
 
+func TestPluginRegistry_ActiveDisplays(t *testing.T) {
+	configManager := core.NewConfigManager()
+	registry := core.NewPluginRegistry(configManager)
+	assert.NoError(t, registry.RegisterDisplay(display.NewRainbowTUIPlugin()))
+	assert.NoError(t, registry.RegisterDisplay(display.NewDashboardPlugin()))
+	assert.NoError(t, registry.RegisterDisplay(display.NewTablePlugin()))
+
+	// Without a list only the configured display is active
+	displays, err := registry.GetActiveDisplays()
+	assert.NoError(t, err)
+	assert.Len(t, displays, 1)
+	assert.Equal(t, "rainbow-display", displays[0].Name())
+
+	configManager.GetConfig().Plugins.Displays = []string{"dashboard", "rainbow-display", "table"}
+	displays, err = registry.GetActiveDisplays()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dashboard", "rainbow-display", "table"}, []string{displays[0].Name(), displays[1].Name(), displays[2].Name()})
+
+	// Switching changes the active display
+	assert.NoError(t, registry.SetActiveDisplay("table"))
+	active, err := registry.GetActiveDisplay()
+	assert.NoError(t, err)
+	assert.Equal(t, "table", active.Name())
+	assert.Error(t, registry.SetActiveDisplay("missing"))
+
+	// Unregistered displays in the list are reported
+	configManager.GetConfig().Plugins.Displays = []string{"table", "missing"}
+	_, err = registry.GetActiveDisplays()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "display plugin 'missing' not found")
+}
+
 func TestPluginRegistry_InitializePlugin(t *testing.T) {
 	configManager := core.NewConfigManager()
 	err := configManager.LoadConfig("")
@@ -286,3 +320,39 @@ func TestPluginRegistry_InitializePlugin(t *testing.T) {
 	// Should be enabled
 	assert.True(t, plugin.IsEnabled())
 }
+
+func TestPluginRegistry_InitializePlugin_DataSourceConfig(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().DataSource.CcusagePath = "/opt/missing/ccusage"
+	registry := core.NewPluginRegistry(configManager)
+
+	// The ccusage CLI data source runs the configured ccusage
+	plugin := datasource.NewCcusageCliPlugin()
+	assert.NoError(t, registry.RegisterDataSource(plugin))
+	assert.NoError(t, registry.InitializePlugin(plugin))
+	assert.ErrorContains(t, plugin.HealthCheck(context.Background()), "/opt/missing/ccusage")
+}
+
+func TestPluginRegistry_Register(t *testing.T) {
+	configManager := core.NewConfigManager()
+	assert.NoError(t, configManager.LoadConfig(""))
+	registry := core.NewPluginRegistry(configManager)
+
+	assert.NoError(t, registry.Register(datasource.NewCcusageCliPlugin()))
+	assert.NoError(t, registry.Register(display.NewTablePlugin()))
+	assert.NoError(t, registry.Register(animation.NewRainbowAnimationPlugin()))
+
+	dataSourceCount, displayCount, animationCount := registry.GetPluginCount()
+	assert.Equal(t, 1, dataSourceCount)
+	assert.Equal(t, 1, displayCount)
+	assert.Equal(t, 1, animationCount)
+
+	err := registry.Register(display.NewTablePlugin())
+	assert.ErrorContains(t, err, "display plugin 'table' already registered")
+}
+
+func TestPluginKindOf(t *testing.T) {
+	assert.Equal(t, domain.PluginKindDataSource, core.PluginKindOf(datasource.NewCcusageCliPlugin()))
+	assert.Equal(t, domain.PluginKindDisplay, core.PluginKindOf(display.NewDashboardPlugin()))
+	assert.Equal(t, domain.PluginKindAnimation, core.PluginKindOf(animation.NewRainbowAnimationPlugin()))
+}
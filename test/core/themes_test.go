@@ -0,0 +1,117 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinThemes(t *testing.T) {
+	themes := core.BuiltinThemes()
+
+	names := make([]string, len(themes))
+	for i, theme := range themes {
+		names[i] = theme.Name
+	}
+	for _, expected := range []string{"rainbow", "pastel", "neon", "monochrome", "solarized", "dracula", "okabe-ito", "viridis", "trans", "pride"} {
+		assert.Contains(t, names, expected)
+	}
+
+	// Every built-in palette passes the animation plugin validation
+	cm := core.NewConfigManager()
+	plugin := animation.NewRainbowAnimationPlugin()
+	assert.NoError(t, cm.ValidateThemes(plugin))
+
+	// Returned themes are copies
+	themes[0].Colors[0] = "#000000"
+	assert.Equal(t, "#FF0000", core.BuiltinThemes()[0].Colors[0])
+}
+
+func TestConfigManager_SetTheme(t *testing.T) {
+	cm := core.NewConfigManager()
+
+	err := cm.SetTheme("Dracula")
+	assert.NoError(t, err)
+
+	config := cm.GetConfig()
+	assert.Equal(t, "dracula", config.Animation.Theme)
+	assert.Equal(t, "#FF5555", config.Animation.Colors[0])
+	assert.Equal(t, config.Animation.Colors, cm.GetAnimationConfig().Colors)
+
+	// Unknown themes are rejected and list the alternatives
+	err = cm.SetTheme("plaid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown theme 'plaid'")
+	assert.Contains(t, err.Error(), "rainbow")
+	assert.Equal(t, "dracula", config.Animation.Theme)
+}
+
+func TestConfigManager_UserThemes(t *testing.T) {
+	cm := core.NewConfigManager()
+	config := cm.GetConfig()
+	config.Themes = []domain.Theme{
+		{Name: "company", Description: "Brand colors", Colors: []string{"#123456", "#654321"}},
+		{Name: "pastel", Colors: []string{"#FFFFFF"}},
+	}
+
+	themes := cm.ListThemes()
+	assert.Len(t, themes, len(core.BuiltinThemes())+1)
+	assert.Equal(t, "company", themes[len(themes)-1].Name)
+
+	// User themes replace built-in themes with the same name
+	pastel, err := cm.GetTheme("pastel")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"#FFFFFF"}, pastel.Colors)
+
+	assert.NoError(t, cm.SetTheme("company"))
+	assert.Equal(t, []string{"#123456", "#654321"}, config.Animation.Colors)
+
+	// Invalid palettes fail validation through the animation plugin
+	config.Themes = append(config.Themes, domain.Theme{Name: "broken", Colors: []string{"red"}})
+	err = cm.ValidateThemes(animation.NewRainbowAnimationPlugin())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid theme 'broken'")
+	assert.Contains(t, err.Error(), "invalid color format")
+}
+
+func TestConfigManager_LoadConfig_Theme(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+animation:
+  theme: sunset
+themes:
+  - name: sunset
+    description: Evening sky
+    colors: ["#FF5E5B", "#FFB55E", "#FFE66D"]
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.LoadConfig(configPath))
+	assert.Equal(t, []string{"#FF5E5B", "#FFB55E", "#FFE66D"}, cm.GetAnimationConfig().Colors)
+
+	// Unknown theme in the file is an error
+	assert.NoError(t, os.WriteFile(configPath, []byte("animation:\n  theme: missing\n"), 0o644))
+	err := core.NewConfigManager().LoadConfig(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown theme 'missing'")
+}
+
+func TestConfigManager_ApplyFlagsToConfig_Theme(t *testing.T) {
+	flagConfig, err := core.ParseCobraFlagsFromArgs([]string{"--theme", "okabe-ito"})
+	assert.NoError(t, err)
+
+	cm := core.NewConfigManager()
+	assert.NoError(t, cm.ApplyFlagsToConfig(flagConfig))
+	assert.Equal(t, "#E69F00", cm.GetAnimationConfig().Colors[0])
+
+	flagConfig, err = core.ParseCobraFlagsFromArgs([]string{"--theme", "nope"})
+	assert.NoError(t, err)
+	err = core.NewConfigManager().ApplyFlagsToConfig(flagConfig)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -168,3 +169,66 @@ func TestCostDataService_Interface(t *testing.T) {
 	err = service.RefreshCostData()
 	assert.Error(t, err)
 }
+
+func TestCombineCostData(t *testing.T) {
+	day1 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	laptop := &domain.CostData{
+		TotalCost:      10,
+		Currency:       "USD",
+		Timestamp:      day1,
+		ModelBreakdown: map[string]float64{"opus": 8, "sonnet": 2},
+		Tokens:         domain.TokenUsage{Input: 100, Output: 10},
+		Daily: []domain.DailyCost{
+			{Date: day2, Cost: 6, ModelBreakdown: map[string]float64{"opus": 6}},
+			{Date: day1, Cost: 4, ModelBreakdown: map[string]float64{"opus": 2, "sonnet": 2}},
+		},
+	}
+	desktop := &domain.CostData{
+		TotalCost:      5,
+		Currency:       "usd",
+		Timestamp:      day2,
+		ModelBreakdown: map[string]float64{"sonnet": 5},
+		Tokens:         domain.TokenUsage{Input: 50, Output: 5},
+		Daily:          []domain.DailyCost{{Date: day2, Cost: 5, ModelBreakdown: map[string]float64{"sonnet": 5}}},
+	}
+
+	combined := domain.CombineCostData([]domain.SourceCost{
+		{Label: "laptop", Data: laptop},
+		{Label: "work", Err: errors.New("timed out")},
+		{Label: "desktop", Data: desktop},
+		{Label: "tokyo", Data: &domain.CostData{TotalCost: 1000, Currency: "JPY"}},
+	})
+
+	assert.Equal(t, 15.0, combined.TotalCost)
+	assert.Equal(t, "USD", combined.Currency)
+	assert.Equal(t, day2, combined.Timestamp)
+	assert.Equal(t, map[string]float64{"opus": 8, "sonnet": 7}, combined.ModelBreakdown)
+	assert.Equal(t, domain.TokenUsage{Input: 150, Output: 15}, combined.Tokens)
+	assert.False(t, combined.Stale)
+
+	// Daily series are merged by date, oldest first
+	assert.Len(t, combined.Daily, 2)
+	assert.Equal(t, day1, combined.Daily[0].Date)
+	assert.Equal(t, 4.0, combined.Daily[0].Cost)
+	assert.Equal(t, 11.0, combined.Daily[1].Cost)
+	assert.Equal(t, map[string]float64{"opus": 6, "sonnet": 5}, combined.Daily[1].ModelBreakdown)
+
+	// Every source is listed, with the reason when it contributed nothing
+	assert.Equal(t, []domain.Contribution{
+		{Label: "laptop", Cost: 10, Tokens: domain.TokenUsage{Input: 100, Output: 10}},
+		{Label: "work", Error: "timed out"},
+		{Label: "desktop", Cost: 5, Tokens: domain.TokenUsage{Input: 50, Output: 5}},
+		{Label: "tokyo", Error: "reports JPY, not USD"},
+	}, combined.Contributors)
+
+	assert.Nil(t, domain.CombineCostData([]domain.SourceCost{{Label: "work", Err: errors.New("timed out")}}))
+
+	// Stale data of one source makes the sum stale
+	combined = domain.CombineCostData([]domain.SourceCost{
+		{Label: "laptop", Data: laptop},
+		{Label: "desktop", Data: &domain.CostData{TotalCost: 5, Currency: "USD", Stale: true}},
+	})
+	assert.Equal(t, 15.0, combined.TotalCost)
+	assert.True(t, combined.Stale)
+}
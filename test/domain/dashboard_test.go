@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardPanes(t *testing.T) {
+	assert.Equal(t, domain.PaneTotal, domain.DashboardPanes()[0])
+	assert.True(t, domain.IsValidDashboardPane(domain.PaneBudget))
+	assert.False(t, domain.IsValidDashboardPane("clock"))
+
+	// Every pane of the default layout is known
+	for _, row := range domain.DefaultDashboardConfig().Layout {
+		for _, column := range row.Columns {
+			assert.True(t, domain.IsValidDashboardPane(column.Pane))
+		}
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	end := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+	daily := []domain.DailyCost{
+		{Date: time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC), Cost: 9},
+		{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Cost: 5},
+		{Date: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), Cost: 2},
+	}
+
+	series := domain.DailySeries(daily, end, 4)
+	assert.Len(t, series, 4)
+	assert.Equal(t, "2025-05-31", series[0].Date.Format("2006-01-02"))
+	assert.Equal(t, "2025-06-03", series[3].Date.Format("2006-01-02"))
+	assert.Equal(t, []float64{0, 5, 0, 2}, []float64{series[0].Cost, series[1].Cost, series[2].Cost, series[3].Cost})
+
+	assert.Nil(t, domain.DailySeries(daily, end, 0))
+}
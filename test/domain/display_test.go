@@ -173,3 +173,30 @@ func TestDisplaySize_EdgeCases(t *testing.T) {
 	assert.Equal(t, 1920, largeSize.Width)
 	assert.Equal(t, 1080, largeSize.Height)
 }
+
+func TestArtGrid_Trim(t *testing.T) {
+	blank := domain.ArtCell{Char: ' '}
+	red := domain.ArtCell{Char: '█', Color: "#FF0000"}
+	grid := &domain.ArtGrid{
+		Width:  5,
+		Height: 4,
+		Rows: [][]domain.ArtCell{
+			{},
+			{blank, blank, red, blank, blank},
+			{blank, red, blank},
+			{},
+		},
+	}
+
+	trimmed := grid.Trim(1, 1)
+	assert.Equal(t, 4, trimmed.Width)
+	assert.Equal(t, 4, trimmed.Height)
+	assert.Equal(t, []domain.ArtCell{blank, blank, red, blank}, trimmed.Rows[1])
+	assert.Equal(t, []domain.ArtCell{blank, red, blank, blank}, trimmed.Rows[2])
+	assert.Equal(t, []domain.ArtCell{blank, blank, blank, blank}, trimmed.Rows[3])
+
+	// Blank art trims to nothing
+	empty := (&domain.ArtGrid{Width: 3, Height: 1, Rows: [][]domain.ArtCell{{blank}}}).Trim(1, 1)
+	assert.Equal(t, 0, empty.Width)
+	assert.Equal(t, 0, empty.Height)
+}
@@ -0,0 +1,105 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRates() *domain.ExchangeRates {
+	return &domain.ExchangeRates{
+		Base: "USD",
+		Date: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Rates: map[string]float64{
+			"JPY": 150.0,
+			"EUR": 0.5,
+		},
+	}
+}
+
+func TestExchangeRates_Rate(t *testing.T) {
+	rates := newTestRates()
+
+	rate, err := rates.Rate("USD", "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, rate)
+
+	// Same currency is always 1
+	rate, err = rates.Rate("jpy", "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	// Cross rates go through the base currency
+	rate, err = rates.Rate("EUR", "JPY")
+	assert.NoError(t, err)
+	assert.InDelta(t, 300.0, rate, 1e-9)
+
+	// Unknown currencies are rejected
+	_, err = rates.Rate("USD", "GBP")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedCurrency)
+}
+
+func TestConvertCostData(t *testing.T) {
+	rates := newTestRates()
+	original := &domain.CostData{
+		TotalCost: 10.0,
+		Currency:  "USD",
+		Timestamp: time.Now(),
+		ModelBreakdown: map[string]float64{
+			"claude-opus":   6.0,
+			"claude-sonnet": 4.0,
+		},
+	}
+
+	converted, err := domain.ConvertCostData(original, rates, "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, 1500.0, converted.TotalCost)
+	assert.Equal(t, "JPY", converted.Currency)
+	assert.Equal(t, 900.0, converted.ModelBreakdown["claude-opus"])
+	assert.Equal(t, 600.0, converted.ModelBreakdown["claude-sonnet"])
+
+	// Conversion details are recorded for display
+	assert.NotNil(t, converted.Conversion)
+	assert.Equal(t, "USD", converted.Conversion.From)
+	assert.Equal(t, "JPY", converted.Conversion.To)
+	assert.Equal(t, 150.0, converted.Conversion.Rate)
+	assert.Equal(t, rates.Date, converted.Conversion.RateDate)
+
+	// The daily series is converted as well
+	daily := &domain.CostData{
+		TotalCost: 2.0,
+		Currency:  "USD",
+		Daily: []domain.DailyCost{
+			{Cost: 2.0, ModelBreakdown: map[string]float64{"claude-opus": 2.0}},
+		},
+	}
+	converted, err = domain.ConvertCostData(daily, rates, "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, converted.Daily[0].Cost)
+	assert.Equal(t, 300.0, converted.Daily[0].ModelBreakdown["claude-opus"])
+	assert.Equal(t, 2.0, daily.Daily[0].Cost)
+
+	// So are the shares of combined sources
+	daily.Contributors = []domain.Contribution{{Label: "laptop", Cost: 2.0}}
+	converted, err = domain.ConvertCostData(daily, rates, "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, converted.Contributors[0].Cost)
+	assert.Equal(t, 2.0, daily.Contributors[0].Cost)
+
+	// The original data is left untouched
+	assert.Equal(t, 10.0, original.TotalCost)
+	assert.Equal(t, 6.0, original.ModelBreakdown["claude-opus"])
+	assert.Nil(t, original.Conversion)
+
+	// Missing currency is treated as USD
+	original.Currency = ""
+	converted, err = domain.ConvertCostData(original, rates, "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, converted.TotalCost)
+
+	// Unsupported target currency
+	_, err = domain.ConvertCostData(original, rates, "GBP")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedCurrency)
+}
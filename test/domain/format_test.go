@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	tests := []struct {
+		code     string
+		symbol   string
+		decimals int
+		position domain.SymbolPosition
+	}{
+		{"USD", "$", 2, domain.SymbolPrefix},
+		{"eur", "€", 2, domain.SymbolPrefix},
+		{"JPY", "¥", 0, domain.SymbolPrefix},
+		{"GBP", "£", 2, domain.SymbolPrefix},
+		{"", "$", 2, domain.SymbolPrefix},      // Empty defaults to USD
+		{"CHF", "CHF", 2, domain.SymbolSuffix}, // Unknown codes are suffixed
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			currency := domain.LookupCurrency(tt.code)
+			assert.Equal(t, tt.symbol, currency.Symbol)
+			assert.Equal(t, tt.decimals, currency.Decimals)
+			assert.Equal(t, tt.position, currency.Position)
+		})
+	}
+
+	assert.True(t, domain.IsKnownCurrency("jpy"))
+	assert.False(t, domain.IsKnownCurrency("CHF"))
+}
+
+func TestFormatCost(t *testing.T) {
+	defaultFormat := domain.DefaultNumberFormat()
+
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		format   domain.NumberFormat
+		expected string
+	}{
+		{"Small USD", 25.75, "USD", defaultFormat, "$25.75"},
+		{"Thousands USD", 1234567.891, "USD", defaultFormat, "$1,234,567.89"},
+		{"Empty currency defaults to USD", 9999.99, "", defaultFormat, "$9,999.99"},
+		{"JPY has zero decimals", 6543.5, "JPY", defaultFormat, "¥6,544"},
+		{"GBP", 12.5, "GBP", defaultFormat, "£12.50"},
+		{"EUR prefix", 1000, "EUR", defaultFormat, "€1,000.00"},
+		{
+			"EUR with European separators",
+			1234.56,
+			"EUR",
+			domain.NumberFormat{ThousandsSeparator: ".", DecimalSeparator: ",", Decimals: -1, SymbolPosition: domain.SymbolSuffix},
+			"1.234,56 €",
+		},
+		{
+			"Configured decimals",
+			41.2,
+			"USD",
+			domain.NumberFormat{ThousandsSeparator: ",", DecimalSeparator: ".", Decimals: 0},
+			"$41",
+		},
+		{
+			"No thousands separator",
+			1234.5,
+			"USD",
+			domain.NumberFormat{DecimalSeparator: ".", Decimals: -1},
+			"$1234.50",
+		},
+		{"Negative amount", -3.5, "USD", defaultFormat, "-$3.50"},
+		{"Negative rounding to zero", -0.001, "USD", defaultFormat, "$0.00"},
+		{"Unknown currency", 7.25, "CHF", defaultFormat, "7.25 CHF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, domain.FormatCost(tt.amount, tt.currency, tt.format))
+		})
+	}
+}
+
+func TestFormatCost_Abbreviate(t *testing.T) {
+	format := domain.DefaultNumberFormat()
+	format.Abbreviate = true
+
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		expected string
+	}{
+		{"Below threshold", 999.99, "USD", "$999.99"},
+		{"Thousands", 1234.56, "USD", "$1.23K"},
+		{"Millions", 2500000, "USD", "$2.50M"},
+		{"Rounds up to next unit", 999999, "USD", "$1.00M"},
+		{"JPY thousands", 150000, "JPY", "¥150K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, domain.FormatCost(tt.amount, tt.currency, format))
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	format := domain.DefaultNumberFormat()
+
+	assert.Equal(t, "0", domain.FormatNumber(0, 0, format))
+	assert.Equal(t, "123", domain.FormatNumber(123, 0, format))
+	assert.Equal(t, "1,000", domain.FormatNumber(1000, 0, format))
+	assert.Equal(t, "12,345.679", domain.FormatNumber(12345.6789, 3, format))
+	assert.Equal(t, "-1,500.00", domain.FormatNumber(-1500, 2, format))
+}
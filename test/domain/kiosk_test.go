@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClockTime(t *testing.T) {
+	offset, err := domain.ParseClockTime("22:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 22*time.Hour+30*time.Minute, offset)
+
+	for _, value := range []string{"", "24:00", "7pm", "22:30:00"} {
+		_, err := domain.ParseClockTime(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 1, hour, minute, 0, 0, time.Local)
+	}
+	evening := domain.QuietHours{Start: "18:00", End: "21:00"}
+	overnight := domain.QuietHours{Start: "22:00", End: "07:00"}
+
+	tests := []struct {
+		name     string
+		quiet    domain.QuietHours
+		time     time.Time
+		expected bool
+	}{
+		{"Within", evening, at(19, 30), true},
+		{"Start is inclusive", evening, at(18, 0), true},
+		{"End is exclusive", evening, at(21, 0), false},
+		{"Before", evening, at(9, 0), false},
+		{"Overnight before midnight", overnight, at(23, 15), true},
+		{"Overnight after midnight", overnight, at(3, 0), true},
+		{"Overnight daytime", overnight, at(12, 0), false},
+		{"Unset", domain.QuietHours{}, at(23, 0), false},
+		{"Invalid", domain.QuietHours{Start: "late", End: "07:00"}, at(23, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.quiet.Contains(tt.time))
+		})
+	}
+}
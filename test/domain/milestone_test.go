@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossedMilestone(t *testing.T) {
+	every := domain.MilestoneConfig{Enabled: true, Every: 100}
+	list := domain.MilestoneConfig{Enabled: true, Amounts: []float64{50, 250}}
+	both := domain.MilestoneConfig{Enabled: true, Every: 100, Amounts: []float64{150}}
+
+	tests := []struct {
+		name      string
+		config    domain.MilestoneConfig
+		previous  float64
+		current   float64
+		milestone float64
+		crossed   bool
+	}{
+		{"Every crossed", every, 95, 101, 100, true},
+		{"Every landed exactly", every, 99.5, 100, 100, true},
+		{"Every not crossed", every, 101, 150, 0, false},
+		{"Every highest of several", every, 95, 420, 400, true},
+		{"Starting exactly on a milestone", every, 100, 120, 0, false},
+		{"List crossed", list, 40, 60, 50, true},
+		{"List highest", list, 40, 300, 250, true},
+		{"List not crossed", list, 60, 200, 0, false},
+		{"List amount above every", both, 140, 160, 150, true},
+		{"Every above list amount", both, 140, 210, 200, true},
+		{"Decrease", every, 150, 50, 0, false},
+		{"Disabled", domain.MilestoneConfig{Every: 100}, 95, 101, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			milestone, crossed := domain.CrossedMilestone(tt.config, tt.previous, tt.current)
+			assert.Equal(t, tt.crossed, crossed)
+			assert.Equal(t, tt.milestone, milestone)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportCostData() *domain.CostData {
+	return &domain.CostData{
+		TotalCost: 60.0,
+		Currency:  "USD",
+		Tokens:    domain.TokenUsage{Input: 600, Output: 60},
+		ModelBreakdown: map[string]float64{
+			"claude-opus":   40.0,
+			"claude-sonnet": 20.0,
+		},
+		Daily: []domain.DailyCost{
+			{
+				Date:           time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC),
+				Cost:           30.0,
+				Tokens:         domain.TokenUsage{Input: 300, Output: 30},
+				ModelBreakdown: map[string]float64{"claude-opus": 30.0},
+			},
+			{
+				Date:           time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+				Cost:           20.0,
+				Tokens:         domain.TokenUsage{Input: 200, Output: 20},
+				ModelBreakdown: map[string]float64{"claude-opus": 10.0, "claude-sonnet": 10.0},
+			},
+			{
+				Date:           time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+				Cost:           10.0,
+				Tokens:         domain.TokenUsage{Input: 100, Output: 10},
+				ModelBreakdown: map[string]float64{"claude-sonnet": 10.0},
+			},
+		},
+	}
+}
+
+func TestReportPeriod_Contains(t *testing.T) {
+	now := time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC)
+
+	assert.True(t, domain.PeriodToday.Contains(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), now))
+	assert.False(t, domain.PeriodToday.Contains(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), now))
+	assert.True(t, domain.PeriodMonth.Contains(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), now))
+	assert.False(t, domain.PeriodMonth.Contains(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), now))
+	assert.True(t, domain.PeriodAll.Contains(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), now))
+}
+
+func TestFilterCostData(t *testing.T) {
+	data := newReportCostData()
+	now := time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC)
+
+	// All time without a model returns the data as reported
+	assert.Same(t, data, domain.FilterCostData(data, domain.PeriodAll, "", now))
+	assert.Nil(t, domain.FilterCostData(nil, domain.PeriodToday, "", now))
+
+	// Month sums the days of the current month
+	month := domain.FilterCostData(data, domain.PeriodMonth, "", now)
+	assert.Equal(t, 30.0, month.TotalCost)
+	assert.Equal(t, domain.TokenUsage{Input: 300, Output: 30}, month.Tokens)
+	assert.Equal(t, map[string]float64{"claude-opus": 10.0, "claude-sonnet": 20.0}, month.ModelBreakdown)
+	assert.Len(t, month.Daily, 2)
+
+	// Today only keeps the current day
+	today := domain.FilterCostData(data, domain.PeriodToday, "", now)
+	assert.Equal(t, 10.0, today.TotalCost)
+	assert.Equal(t, 110, today.Tokens.Total())
+	assert.Len(t, today.Daily, 1)
+
+	// A model filter narrows the total and the daily series to that model
+	opus := domain.FilterCostData(data, domain.PeriodAll, "claude-opus", now)
+	assert.Equal(t, 40.0, opus.TotalCost)
+	assert.Equal(t, map[string]float64{"claude-opus": 40.0}, opus.ModelBreakdown)
+	assert.Equal(t, domain.TokenUsage{}, opus.Tokens)
+	assert.Equal(t, []float64{30.0, 10.0, 0.0}, []float64{opus.Daily[0].Cost, opus.Daily[1].Cost, opus.Daily[2].Cost})
+
+	opusMonth := domain.FilterCostData(data, domain.PeriodMonth, "claude-opus", now)
+	assert.Equal(t, 10.0, opusMonth.TotalCost)
+
+	// The original data is left untouched
+	assert.Equal(t, 60.0, data.TotalCost)
+	assert.Equal(t, 40.0, data.ModelBreakdown["claude-opus"])
+}
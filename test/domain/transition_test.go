@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEase(t *testing.T) {
+	easings := []domain.Easing{domain.EasingLinear, domain.EasingEaseIn, domain.EasingEaseOut, domain.EasingEaseInOut}
+
+	for _, easing := range easings {
+		t.Run(string(easing), func(t *testing.T) {
+			assert.True(t, domain.IsValidEasing(easing))
+
+			// Every curve starts at 0, ends at 1 and never moves backwards
+			assert.Equal(t, 0.0, domain.Ease(easing, 0))
+			assert.InDelta(t, 1.0, domain.Ease(easing, 1), 1e-9)
+			previous := 0.0
+			for step := 1; step <= 20; step++ {
+				value := domain.Ease(easing, float64(step)/20)
+				assert.GreaterOrEqual(t, value, previous)
+				previous = value
+			}
+
+			// Progress outside [0, 1] is clamped
+			assert.Equal(t, 0.0, domain.Ease(easing, -0.5))
+			assert.InDelta(t, 1.0, domain.Ease(easing, 2), 1e-9)
+		})
+	}
+
+	assert.Equal(t, 0.5, domain.Ease(domain.EasingLinear, 0.5))
+	assert.Less(t, domain.Ease(domain.EasingEaseIn, 0.5), 0.5)
+	assert.Greater(t, domain.Ease(domain.EasingEaseOut, 0.5), 0.5)
+	assert.InDelta(t, 0.5, domain.Ease(domain.EasingEaseInOut, 0.5), 1e-9)
+	assert.False(t, domain.IsValidEasing("bounce"))
+}
+
+func TestInterpolate(t *testing.T) {
+	assert.Equal(t, 41.20, domain.Interpolate(41.20, 43.87, 0))
+	assert.Equal(t, 43.87, domain.Interpolate(41.20, 43.87, 1))
+	assert.InDelta(t, 42.535, domain.Interpolate(41.20, 43.87, 0.5), 1e-9)
+	assert.InDelta(t, 5.0, domain.Interpolate(10, 0, 0.5), 1e-9)
+}
+
+func TestChangedChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		next     string
+		expected []bool
+	}{
+		{
+			name:     "Same length",
+			previous: "$41.20",
+			next:     "$43.87",
+			expected: []bool{false, false, true, false, true, true},
+		},
+		{
+			name:     "Longer next text is compared right-aligned after the symbol",
+			previous: "$99.50",
+			next:     "$100.50",
+			expected: []bool{false, true, true, true, false, false, false},
+		},
+		{
+			name:     "Shorter next text",
+			previous: "$100.00",
+			next:     "$99.00",
+			expected: []bool{false, true, true, false, false, false},
+		},
+		{
+			name:     "Unchanged",
+			previous: "$1.00",
+			next:     "$1.00",
+			expected: []bool{false, false, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, domain.ChangedChars(tt.previous, tt.next))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package asciicast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/asciicast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := asciicast.NewWriter(&buf, asciicast.Header{Width: 80, Height: 24, Title: "cost"})
+	assert.NoError(t, err)
+	assert.NoError(t, writer.WriteOutput(0, "hello"))
+	assert.NoError(t, writer.WriteOutput(1500*time.Millisecond, "\x1b[31mworld"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+
+	var header asciicast.Header
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, asciicast.Header{Version: 2, Width: 80, Height: 24, Title: "cost"}, header)
+
+	var event []interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	assert.Equal(t, []interface{}{1.5, "o", "\x1b[31mworld"}, event)
+}
+
+func TestFrame(t *testing.T) {
+	assert.Equal(t, "\x1b[Hab\x1b[K\r\ncd\x1b[K\x1b[J", asciicast.Frame("ab\ncd"))
+}
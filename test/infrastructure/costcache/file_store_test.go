@@ -0,0 +1,60 @@
+package costcache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/costcache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nested", "cost.json")
+	store := costcache.NewFileStore(path)
+	assert.Equal(t, path, store.Path())
+
+	// A missing file has nothing cached
+	cached, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+
+	savedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Save(ctx, domain.CachedCost{
+		SavedAt: savedAt,
+		Source:  "ccusage-cli",
+		Cost:    &domain.CostData{TotalCost: 12.5, Currency: "USD", Stale: true},
+	}))
+	assert.NoError(t, store.Save(ctx, domain.CachedCost{
+		SavedAt: savedAt.Add(time.Minute),
+		Source:  "ccusage-cli",
+		Cost:    &domain.CostData{TotalCost: 13, Currency: "USD"},
+	}))
+
+	cached, err = store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, savedAt.Add(time.Minute), cached.SavedAt)
+	assert.Equal(t, "ccusage-cli", cached.Source)
+	assert.Equal(t, 13.0, cached.Cost.TotalCost)
+	assert.False(t, cached.Cost.Stale)
+
+	// Only the cache file is left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.True(t, cached.IsStale(savedAt.Add(2*time.Minute), 10*time.Second))
+	assert.False(t, cached.IsStale(savedAt.Add(time.Minute+5*time.Second), 10*time.Second))
+}
+
+func TestFileStore_Load_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cost.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := costcache.NewFileStore(path).Load(context.Background())
+	assert.ErrorContains(t, err, "failed to parse cost cache")
+}
@@ -0,0 +1,57 @@
+package history_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore_RecordAndList(t *testing.T) {
+	ctx := context.Background()
+	store := history.NewFileStore(filepath.Join(t.TempDir(), "nested", "history.jsonl"))
+
+	// A missing file has no entries
+	entries, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	first := domain.HistoryEntry{
+		Time:     time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Type:     domain.HistoryEventMilestone,
+		Amount:   100,
+		Cost:     101.5,
+		Currency: "USD",
+	}
+	second := first
+	second.Time = first.Time.Add(time.Hour)
+	second.Amount = 200
+	second.Cost = 204
+
+	assert.NoError(t, store.Record(ctx, first))
+	assert.NoError(t, store.Record(ctx, second))
+
+	entries, err = store.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.HistoryEntry{first, second}, entries)
+
+	// Each entry is a single JSON line
+	content, err := os.ReadFile(store.Path())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), "\n"))
+}
+
+func TestFileStore_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	assert.NoError(t, os.WriteFile(path, []byte("{\"type\":\"milestone\"}\nnot json\n"), 0o644))
+
+	_, err := history.NewFileStore(path).List(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
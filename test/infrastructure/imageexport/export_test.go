@@ -0,0 +1,108 @@
+package imageexport_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/imageexport"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGrid returns a grid with a red block, a green "$" and blank cells
+func newGrid(color string) *domain.ArtGrid {
+	return &domain.ArtGrid{
+		Width:  3,
+		Height: 2,
+		Rows: [][]domain.ArtCell{
+			{{Char: '█', Color: color}, {Char: '█', Color: color}, {Char: '$', Color: "#00FF00"}},
+			{{Char: ' '}},
+		},
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	options := imageexport.Options{Scale: 1, Background: "#101010"}
+	assert.NoError(t, imageexport.WriteSVG(&buf, newGrid("#FF0000"), options))
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, `width="18" height="24"`)
+	assert.Contains(t, svg, `fill="#101010"`)
+
+	// Equally colored cells share one text element
+	assert.Contains(t, svg, `<text x="0" y="9" fill="#FF0000" textLength="12" lengthAdjust="spacingAndGlyphs">██</text>`)
+	assert.Contains(t, svg, `<text x="12" y="9" fill="#00FF00" textLength="6" lengthAdjust="spacingAndGlyphs">$</text>`)
+	assert.Equal(t, 2, strings.Count(svg, "<text"))
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	options := imageexport.Options{Scale: 2, Background: "#000000"}
+	assert.NoError(t, imageexport.WritePNG(&buf, newGrid("#FF0000"), options))
+
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	bounds := img.Bounds()
+	assert.Equal(t, 3*imageexport.CellWidth*2, bounds.Dx())
+	assert.Equal(t, 2*imageexport.CellHeight*2, bounds.Dy())
+
+	rgba := func(x, y int) color.RGBA {
+		return color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+	}
+	red := color.RGBA{R: 0xFF, A: 0xFF}
+	black := color.RGBA{A: 0xFF}
+
+	// Blocks fill their whole cell
+	assert.Equal(t, red, rgba(0, 0))
+	assert.Equal(t, red, rgba(2*imageexport.CellWidth*2-1, imageexport.CellHeight*2-1))
+
+	// Other characters are drawn with the font, leaving the background around them
+	dollar := 2 * imageexport.CellWidth * 2
+	green := 0
+	for y := 0; y < imageexport.CellHeight*2; y++ {
+		for x := dollar; x < dollar+imageexport.CellWidth*2; x++ {
+			if rgba(x, y) == (color.RGBA{G: 0xFF, A: 0xFF}) {
+				green++
+			}
+		}
+	}
+	assert.Greater(t, green, 0)
+	assert.Equal(t, black, rgba(dollar, 0))
+	assert.Equal(t, black, rgba(0, bounds.Dy()-1))
+}
+
+func TestWriteGIF(t *testing.T) {
+	var buf bytes.Buffer
+	options := imageexport.Options{Scale: 1, Background: "#000000", Delay: 200 * time.Millisecond}
+	grids := []*domain.ArtGrid{newGrid("#FF0000"), newGrid("#0000FF"), newGrid("#FF0000")}
+	assert.NoError(t, imageexport.WriteGIF(&buf, grids, options))
+
+	animation, err := gif.DecodeAll(&buf)
+	assert.NoError(t, err)
+	assert.Len(t, animation.Image, 3)
+	assert.Equal(t, []int{20, 20, 20}, animation.Delay)
+	assert.Equal(t, color.RGBA{B: 0xFF, A: 0xFF}, color.RGBAModel.Convert(animation.Image[1].At(0, 0)))
+
+	assert.EqualError(t, imageexport.WriteGIF(&buf, nil, options), "no frames to write")
+}
+
+func TestExportOptions(t *testing.T) {
+	var buf bytes.Buffer
+	grid := newGrid("#FF0000")
+
+	err := imageexport.WritePNG(&buf, grid, imageexport.Options{Scale: 0, Background: "#000000"})
+	assert.EqualError(t, err, "scale must be at least 1")
+
+	err = imageexport.WriteSVG(&buf, grid, imageexport.Options{Scale: 1, Background: "black"})
+	assert.EqualError(t, err, "invalid background color: 'black' is not a #RRGGBB color")
+
+	defaults := imageexport.DefaultOptions()
+	assert.NoError(t, imageexport.WritePNG(&buf, grid, defaults))
+}
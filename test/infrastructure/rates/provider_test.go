@@ -0,0 +1,71 @@
+package rates_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/rates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileProvider_GetRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	content := `{"base": "usd", "date": "2026-10-01", "rates": {"jpy": 150.25, "EUR": 0.92}}`
+	err := os.WriteFile(path, []byte(content), 0o644)
+	assert.NoError(t, err)
+
+	provider := rates.NewFileProvider(path)
+	assert.Equal(t, "file", provider.Name())
+
+	exchangeRates, err := provider.GetRates(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", exchangeRates.Base)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), exchangeRates.Date)
+	assert.Equal(t, 150.25, exchangeRates.Rates["JPY"])
+	assert.Equal(t, 0.92, exchangeRates.Rates["EUR"])
+}
+
+func TestFileProvider_GetRates_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	// Missing file
+	_, err := rates.NewFileProvider(filepath.Join(dir, "missing.json")).GetRates(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read rate table")
+
+	// Malformed JSON
+	malformed := filepath.Join(dir, "malformed.json")
+	assert.NoError(t, os.WriteFile(malformed, []byte("{"), 0o644))
+	_, err = rates.NewFileProvider(malformed).GetRates(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse rate table")
+
+	// Invalid date
+	badDate := filepath.Join(dir, "bad_date.json")
+	assert.NoError(t, os.WriteFile(badDate, []byte(`{"date": "yesterday", "rates": {"JPY": 150}}`), 0o644))
+	_, err = rates.NewFileProvider(badDate).GetRates(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid rate date")
+
+	// Non-positive rate
+	badRate := filepath.Join(dir, "bad_rate.json")
+	assert.NoError(t, os.WriteFile(badRate, []byte(`{"rates": {"JPY": -1}}`), 0o644))
+	_, err = rates.NewFileProvider(badRate).GetRates(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be positive")
+}
+
+func TestStaticProvider_GetRates(t *testing.T) {
+	provider, err := rates.NewStaticProvider("", "2026-09-30", map[string]float64{"eur": 0.9})
+	assert.NoError(t, err)
+	assert.Equal(t, "static", provider.Name())
+
+	exchangeRates, err := provider.GetRates(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", exchangeRates.Base) // Defaults to USD
+	assert.Equal(t, 0.9, exchangeRates.Rates["EUR"])
+	assert.Equal(t, 2026, exchangeRates.Date.Year())
+}
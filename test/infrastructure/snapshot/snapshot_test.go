@@ -0,0 +1,156 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDir_WriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team")
+	dir := snapshot.NewDir(path)
+
+	// A missing directory cannot be read
+	_, err := dir.Read()
+	assert.Error(t, err)
+
+	writtenAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, member := range []string{"bob", "alice"} {
+		assert.NoError(t, dir.Write(domain.TeamSnapshot{
+			Member:    member,
+			Host:      member + "-laptop",
+			WrittenAt: writtenAt,
+			Cost:      &domain.CostData{TotalCost: 10, Currency: "USD"},
+		}))
+	}
+
+	// A newer snapshot replaces the old one
+	assert.NoError(t, dir.Write(domain.TeamSnapshot{Member: "bob", WrittenAt: writtenAt.Add(time.Hour), Cost: &domain.CostData{TotalCost: 12}}))
+
+	snapshots, err := dir.Read()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, "alice", snapshots[0].Member)
+	assert.Equal(t, "alice-laptop", snapshots[0].Host)
+	assert.Equal(t, writtenAt, snapshots[0].WrittenAt)
+	assert.Equal(t, 12.0, snapshots[1].Cost.TotalCost)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Error(t, dir.Write(domain.TeamSnapshot{Member: "../escape"}))
+	assert.Error(t, dir.Write(domain.TeamSnapshot{}))
+}
+
+func TestDir_Read_SkipsBadFiles(t *testing.T) {
+	path := t.TempDir()
+	dir := snapshot.NewDir(path)
+	assert.NoError(t, dir.Write(domain.TeamSnapshot{Member: "alice", Cost: &domain.CostData{TotalCost: 10}}))
+
+	// A conflicted copy made by a sync tool claims another member's name
+	content, err := os.ReadFile(filepath.Join(path, "alice.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "alice (conflicted copy).json"), content, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "broken.json"), []byte("{"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(path, "notes.txt"), []byte("hello"), 0o644))
+
+	snapshots, err := dir.Read()
+	assert.Len(t, snapshots, 1)
+	assert.ErrorContains(t, err, `belongs to "alice", not "alice (conflicted copy)"`)
+	assert.ErrorContains(t, err, "failed to parse snapshot")
+	assert.NotContains(t, err.Error(), "notes.txt")
+}
+
+// switchDataSource fails until it is switched on
+type switchDataSource struct {
+	mu    sync.Mutex
+	on    bool
+	stale bool
+}
+
+func (s *switchDataSource) Name() string                                   { return "switch" }
+func (s *switchDataSource) Version() string                                { return "1.0.0" }
+func (s *switchDataSource) Description() string                            { return "Fails until switched on" }
+func (s *switchDataSource) Initialize(config map[string]interface{}) error { return nil }
+func (s *switchDataSource) Shutdown() error                                { return nil }
+func (s *switchDataSource) IsEnabled() bool                                { return true }
+func (s *switchDataSource) SupportsRealtime() bool                         { return false }
+func (s *switchDataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+func (s *switchDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.on {
+		return nil, errors.New("ccusage not found")
+	}
+	return &domain.CostData{TotalCost: 42, Currency: "USD", Stale: s.stale}, nil
+}
+
+func (s *switchDataSource) switchOn() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.on = true
+}
+
+func TestPublisher_Run(t *testing.T) {
+	dir := snapshot.NewDir(t.TempDir())
+	source := &switchDataSource{}
+	publisher := snapshot.NewPublisher(dir, source, "alice", "alice-laptop")
+	assert.ErrorContains(t, publisher.Publish(context.Background()), "ccusage not found")
+
+	// Failures are kept for the caller to report
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		publisher.Run(ctx, time.Millisecond)
+	}()
+	// Stop publishing before the directory is removed
+	defer func() {
+		cancel()
+		<-done
+	}()
+	assert.Eventually(t, func() bool { return publisher.Err() != nil }, time.Second, time.Millisecond)
+
+	// A later success clears the failure
+	source.switchOn()
+	assert.Eventually(t, func() bool { return publisher.Err() == nil }, time.Second, time.Millisecond)
+
+	snapshots, err := dir.Read()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, "alice", snapshots[0].Member)
+	assert.Equal(t, "alice-laptop", snapshots[0].Host)
+	assert.Equal(t, 42.0, snapshots[0].Cost.TotalCost)
+	assert.WithinDuration(t, time.Now(), snapshots[0].WrittenAt, time.Minute)
+}
+
+func TestPublisher_Publish_SkipsStaleData(t *testing.T) {
+	dir := snapshot.NewDir(t.TempDir())
+	source := &switchDataSource{on: true, stale: true}
+	publisher := snapshot.NewPublisher(dir, source, "alice", "alice-laptop")
+
+	// Stale data is not shared as if it were fetched now
+	assert.NoError(t, publisher.Publish(context.Background()))
+	snapshots, err := dir.Read()
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	source.stale = false
+	assert.NoError(t, publisher.Publish(context.Background()))
+	snapshots, err = dir.Read()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
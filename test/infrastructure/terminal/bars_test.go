@@ -0,0 +1,24 @@
+package terminal_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarRows(t *testing.T) {
+	days := []domain.DailyCost{{Cost: 10}, {Cost: 0}, {Cost: 5}, {Cost: 0.01}}
+
+	// Bars are scaled to the highest day, with small costs still visible
+	assert.Equal(t, []string{
+		"█   ",
+		"█ █▁",
+	}, terminal.BarRows(days, 2, 0))
+
+	assert.Equal(t, []string{
+		"█   ▄ ▁",
+	}, terminal.BarRows(days, 1, 1))
+	assert.Nil(t, terminal.BarRows(days, 0, 0))
+}
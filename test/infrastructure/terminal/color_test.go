@@ -0,0 +1,111 @@
+package terminal_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+// envFrom builds a getenv function backed by a map
+func envFrom(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestDetectColorProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       domain.ColorMode
+		env        map[string]string
+		isTerminal bool
+		expected   domain.ColorProfile
+	}{
+		{"Truecolor via COLORTERM", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, domain.ColorProfileTrueColor},
+		{"256 colors via TERM", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color"}, true, domain.ColorProfileANSI256},
+		{"Basic terminal", domain.ColorModeAuto, map[string]string{"TERM": "xterm"}, true, domain.ColorProfileANSI},
+		{"Screen over SSH", domain.ColorModeAuto, map[string]string{"TERM": "screen"}, true, domain.ColorProfileANSI},
+		{"Dumb terminal", domain.ColorModeAuto, map[string]string{"TERM": "dumb"}, true, domain.ColorProfileNone},
+		{"Apple Terminal", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "Apple_Terminal"}, true, domain.ColorProfileANSI256},
+		{"Kitty", domain.ColorModeAuto, map[string]string{"TERM": "xterm-kitty"}, true, domain.ColorProfileTrueColor},
+		{"Not a terminal", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color"}, false, domain.ColorProfileNone},
+		{"NO_COLOR", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, true, domain.ColorProfileNone},
+		{"NO_COLOR beats CLICOLOR_FORCE", domain.ColorModeAuto, map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, true, domain.ColorProfileNone},
+		{"CLICOLOR=0", domain.ColorModeAuto, map[string]string{"TERM": "xterm", "CLICOLOR": "0"}, true, domain.ColorProfileNone},
+		{"CLICOLOR_FORCE without terminal", domain.ColorModeAuto, map[string]string{"TERM": "xterm-256color", "CLICOLOR_FORCE": "1"}, false, domain.ColorProfileANSI256},
+		{"CLICOLOR_FORCE=0 is ignored", domain.ColorModeAuto, map[string]string{"TERM": "xterm", "CLICOLOR_FORCE": "0"}, false, domain.ColorProfileNone},
+		{"Never", domain.ColorModeNever, map[string]string{"COLORTERM": "truecolor"}, true, domain.ColorProfileNone},
+		{"Always ignores NO_COLOR", domain.ColorModeAlways, map[string]string{"COLORTERM": "truecolor", "NO_COLOR": "1"}, false, domain.ColorProfileTrueColor},
+		{"Always upgrades unknown terminals", domain.ColorModeAlways, map[string]string{}, false, domain.ColorProfileANSI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := terminal.DetectColorProfile(tt.mode, envFrom(tt.env), tt.isTerminal)
+			assert.Equal(t, tt.expected, profile)
+		})
+	}
+}
+
+func TestAdaptColor(t *testing.T) {
+	// Truecolor keeps the palette color as-is
+	assert.Equal(t, lipgloss.Color("#FF8000"), terminal.AdaptColor("#FF8000", domain.ColorProfileTrueColor))
+
+	// 256 colors map to the nearest xterm color
+	assert.Equal(t, lipgloss.ANSIColor(196), terminal.AdaptColor("#FF0000", domain.ColorProfileANSI256))
+	assert.Equal(t, lipgloss.ANSIColor(21), terminal.AdaptColor("#0000FF", domain.ColorProfileANSI256))
+
+	// 16 colors map to the nearest basic color
+	assert.Equal(t, lipgloss.ANSIColor(9), terminal.AdaptColor("#FF0000", domain.ColorProfileANSI))
+	assert.Equal(t, lipgloss.ANSIColor(10), terminal.AdaptColor("#00FF00", domain.ColorProfileANSI))
+
+	// No color profile drops colors entirely
+	assert.Equal(t, lipgloss.NoColor{}, terminal.AdaptColor("#FF0000", domain.ColorProfileNone))
+}
+
+func TestNewRenderer(t *testing.T) {
+	tests := []struct {
+		profile  domain.ColorProfile
+		sequence string
+	}{
+		{domain.ColorProfileTrueColor, "38;2;255;0;0"},
+		{domain.ColorProfileANSI256, "38;5;196"},
+		{domain.ColorProfileANSI, "91"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			style := terminal.NewRenderer(tt.profile).NewStyle().Foreground(terminal.AdaptColor("#FF0000", tt.profile))
+			assert.Contains(t, style.Render("x"), tt.sequence)
+		})
+	}
+
+	// Uncolored output carries no escape sequences
+	style := terminal.NewRenderer(domain.ColorProfileNone).NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	assert.Equal(t, "x", style.Render("x"))
+}
+
+func TestDimColor(t *testing.T) {
+	assert.Equal(t, "#FF8000", terminal.DimColor("#FF8000", 1))
+	assert.Equal(t, "#804000", terminal.DimColor("#ff8000", 0.5))
+	assert.Equal(t, "#000000", terminal.DimColor("#FF8000", 0))
+
+	// Brightness is clamped and non-hex colors are left alone
+	assert.Equal(t, "#FF8000", terminal.DimColor("#FF8000", 2))
+	assert.Equal(t, "red", terminal.DimColor("red", 0.5))
+}
+
+func TestParseHexColor(t *testing.T) {
+	parsed, err := terminal.ParseHexColor("#ff8000")
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0xFF, G: 0x80, B: 0x00, A: 0xFF}, parsed)
+
+	for _, invalid := range []string{"red", "#FF80", "#FF800000", "FF8000"} {
+		_, err := terminal.ParseHexColor(invalid)
+		assert.ErrorContains(t, err, "is not a #RRGGBB color", invalid)
+	}
+}
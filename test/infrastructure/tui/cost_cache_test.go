@@ -0,0 +1,123 @@
+package tui_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCostCache keeps cached cost data in memory
+type memoryCostCache struct {
+	mu     sync.Mutex
+	cached *domain.CachedCost
+	saved  []domain.CachedCost
+}
+
+func (m *memoryCostCache) Save(ctx context.Context, cached domain.CachedCost) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = append(m.saved, cached)
+	return nil
+}
+
+func (m *memoryCostCache) Load(ctx context.Context) (*domain.CachedCost, error) {
+	return m.cached, nil
+}
+
+func (m *memoryCostCache) savedCosts() []domain.CachedCost {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saved
+}
+
+// gatedDataSource returns its data once released
+type gatedDataSource struct {
+	dailyDataSource
+	release chan struct{}
+}
+
+func (g *gatedDataSource) Name() string { return "gated-datasource" }
+func (g *gatedDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	<-g.release
+	return g.dailyDataSource.FetchCostData(ctx)
+}
+
+// startModel runs the commands of Init concurrently, delivering their messages on the channel
+func startModel(model *tui.Model) <-chan tea.Msg {
+	msgs := make(chan tea.Msg, 16)
+	batch, _ := model.Init()().(tea.BatchMsg)
+	for _, cmd := range batch {
+		if cmd != nil {
+			go func() { msgs <- cmd() }()
+		}
+	}
+	return msgs
+}
+
+// updateUntil feeds messages to the model until the view contains text
+func updateUntil(t *testing.T, model *tui.Model, msgs <-chan tea.Msg, text string) {
+	timeout := time.After(2 * time.Second)
+	for !strings.Contains(model.View(), text) {
+		select {
+		case msg := <-msgs:
+			model.Update(msg)
+		case <-timeout:
+			t.Fatalf("view never contained %q:\n%s", text, model.View())
+		}
+	}
+}
+
+func TestModel_CachedCostAtStartup(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "gated-datasource"
+	config.Plugins.Display = "table"
+	config.Plugins.Displays = []string{"table"}
+	registry := core.NewPluginRegistry(configManager)
+
+	dataSource := &gatedDataSource{release: make(chan struct{})}
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	tablePlugin := display.NewTablePlugin()
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(tablePlugin))
+	assert.NoError(t, registry.InitializeAll())
+
+	savedAt := time.Now().Add(-time.Hour)
+	costCache := &memoryCostCache{cached: &domain.CachedCost{
+		SavedAt: savedAt,
+		Source:  "gated-datasource",
+		Cost:    &domain.CostData{TotalCost: 12.5, Currency: "USD", Timestamp: savedAt},
+	}}
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.SetCostCache(costCache)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The cached cost is shown while the data source is still fetching
+	msgs := startModel(model)
+	updateUntil(t, model, msgs, "Cached data from")
+	assert.Contains(t, model.View(), "$12.50")
+	assert.Empty(t, costCache.savedCosts())
+
+	// Fresh data replaces it and is kept for the next startup
+	close(dataSource.release)
+	updateUntil(t, model, msgs, "claude-opus-4")
+	assert.NotContains(t, model.View(), "Cached data from")
+	assert.Equal(t, 30.0, model.Status().CurrentCost.TotalCost)
+
+	saved := costCache.savedCosts()
+	assert.Len(t, saved, 1)
+	assert.Equal(t, "gated-datasource", saved[0].Source)
+	assert.Equal(t, 30.0, saved[0].Cost.TotalCost)
+}
@@ -0,0 +1,71 @@
+package tui_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_DisplaySwitching(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "daily-datasource"
+	config.Plugins.Displays = []string{"rainbow-display", "dashboard", "table"}
+	registry := core.NewPluginRegistry(configManager)
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	assert.NoError(t, registry.RegisterDataSource(&dailyDataSource{}))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	for _, plugin := range []interfaces.DisplayPlugin{display.NewRainbowTUIPlugin(), display.NewDashboardPlugin(), display.NewTablePlugin()} {
+		assert.NoError(t, registry.RegisterDisplay(plugin))
+		assert.NoError(t, registry.InitializePlugin(plugin))
+	}
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	refresh(t, model)
+	assert.Contains(t, model.View(), "Breakdown")
+
+	// The animation state carries over when switching
+	paused := func() bool {
+		pressKey(model, "?")
+		defer pressKey(model, "?")
+		return strings.Contains(model.View(), "Animation  paused")
+	}
+	pressKey(model, " ")
+	assert.True(t, paused())
+
+	// Tab moves to the next display
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, "dashboard", config.Plugins.Display)
+	view := model.View()
+	assert.Contains(t, view, "Models")
+	assert.Contains(t, view, "Display 2/3 · dashboard")
+	assert.True(t, paused())
+
+	// Number keys pick a display by position
+	pressKey(model, "3")
+	assert.Equal(t, "table", config.Plugins.Display)
+	assert.Contains(t, model.View(), "Share")
+
+	// Shift+Tab goes back and wraps around
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, "dashboard", config.Plugins.Display)
+	pressKey(model, "1")
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, "table", config.Plugins.Display)
+
+	// Positions without a display are ignored
+	pressKey(model, "9")
+	assert.Equal(t, "table", config.Plugins.Display)
+	assert.True(t, paused())
+}
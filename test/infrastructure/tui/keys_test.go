@@ -0,0 +1,74 @@
+package tui_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultKeyMap(t *testing.T) {
+	keys := tui.DefaultKeyMap()
+
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, keys.Quit))
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyCtrlC}, keys.Quit))
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, keys.Pause))
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}, keys.Help))
+
+	// Every binding shows up in the help overlay
+	count := 0
+	for _, group := range keys.FullHelp() {
+		count += len(group)
+	}
+	assert.Equal(t, len(tui.KeyActions()), count)
+}
+
+func TestNewKeyMap(t *testing.T) {
+	keys, err := tui.NewKeyMap(map[string][]string{
+		"quit":  {"x", "ctrl+c"},
+		"pause": {"space"},
+		"help":  {"h"},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, keys.Quit))
+	assert.False(t, key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, keys.Quit))
+	assert.Equal(t, "x", keys.Quit.Help().Key)
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, keys.Pause))
+	assert.Equal(t, "space", keys.Pause.Help().Key)
+	assert.Equal(t, "h", keys.Help.Help().Key)
+}
+
+func TestNewKeyMap_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		errMsg    string
+	}{
+		{
+			name:      "Unknown action",
+			overrides: map[string][]string{"explode": {"x"}},
+			errMsg:    "unknown key action 'explode'",
+		},
+		{
+			name:      "No keys",
+			overrides: map[string][]string{"quit": {}},
+			errMsg:    "no keys bound to action 'quit'",
+		},
+		{
+			name:      "Conflicting keys",
+			overrides: map[string][]string{"refresh": {"t"}},
+			errMsg:    "key 't' is bound to both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tui.NewKeyMap(tt.overrides)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
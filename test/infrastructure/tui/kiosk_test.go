@@ -0,0 +1,154 @@
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyDataSource returns daily cost data, or its error when set
+type flakyDataSource struct {
+	dailyDataSource
+	err error
+}
+
+func (f *flakyDataSource) Name() string { return "flaky-datasource" }
+func (f *flakyDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.dailyDataSource.FetchCostData(ctx)
+}
+
+// setupKioskModel creates a kiosk model with fast timers
+func setupKioskModel(t *testing.T, dataSource *flakyDataSource) (*tui.Model, *core.ConfigManager) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "flaky-datasource"
+	config.Plugins.Displays = []string{"rainbow-display", "table"}
+	config.Kiosk.Enabled = true
+	config.Kiosk.RotateInterval = time.Millisecond
+	config.Kiosk.RetryInterval = time.Millisecond
+	config.Kiosk.DriftInterval = time.Hour
+	registry := core.NewPluginRegistry(configManager)
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	rainbowPlugin := display.NewRainbowTUIPlugin()
+	tablePlugin := display.NewTablePlugin()
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(rainbowPlugin))
+	assert.NoError(t, registry.RegisterDisplay(tablePlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(rainbowPlugin))
+	assert.NoError(t, registry.InitializePlugin(tablePlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return model, configManager
+}
+
+// fetch runs a fetch command and returns the command the model responds with
+func fetch(model *tui.Model, cmd tea.Cmd) tea.Cmd {
+	_, next := model.Update(cmd())
+	return next
+}
+
+func TestModel_KioskRotation(t *testing.T) {
+	model, configManager := setupKioskModel(t, &flakyDataSource{})
+	config := configManager.GetConfig()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	rotate := fetch(model, cmd)
+	assert.NotNil(t, rotate)
+
+	// No hint bar or notices
+	view := model.View()
+	assert.Contains(t, view, "Breakdown · all")
+	assert.NotContains(t, view, "quit")
+
+	// Each timer moves to the next report period
+	rotate = fetch(model, rotate)
+	assert.Contains(t, model.View(), "Breakdown · month")
+	rotate = fetch(model, rotate)
+	assert.Contains(t, model.View(), "Breakdown · today")
+	assert.Equal(t, "rainbow-display", config.Plugins.Display)
+
+	// After the last period the next display is shown
+	fetch(model, rotate)
+	assert.Equal(t, "table", config.Plugins.Display)
+	view = model.View()
+	assert.Contains(t, view, "Share")
+	assert.NotContains(t, view, "Display 2/2")
+}
+
+func TestModel_KioskReconnect(t *testing.T) {
+	dataSource := &flakyDataSource{err: errors.New("ccusage not found")}
+	model, configManager := setupKioskModel(t, dataSource)
+	configManager.GetConfig().Kiosk.RotateInterval = 0
+
+	// Without data the error is shown until a retry succeeds
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	retry := fetch(model, cmd)
+	assert.NotNil(t, retry)
+	view := model.View()
+	assert.Contains(t, view, "ccusage not found")
+	assert.Contains(t, view, "Reconnecting every 1ms")
+	assert.NotContains(t, view, "Press")
+
+	dataSource.err = nil
+	fetch(model, fetch(model, retry))
+	assert.Contains(t, model.View(), "Breakdown · all")
+
+	// Later errors keep the last cost on screen
+	dataSource.err = errors.New("timeout")
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	retry = fetch(model, cmd)
+	view = model.View()
+	assert.Contains(t, view, "Breakdown · all")
+	assert.Contains(t, view, "Data unavailable")
+
+	dataSource.err = nil
+	fetch(model, fetch(model, retry))
+	assert.NotContains(t, model.View(), "Data unavailable")
+}
+
+func TestModel_KioskDriftAndQuietHours(t *testing.T) {
+	model, configManager := setupKioskModel(t, &flakyDataSource{})
+	config := configManager.GetConfig()
+	config.Kiosk.RotateInterval = 0
+	config.Display.Transition.Duration = 0
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	fetch(model, cmd)
+
+	// The drifting art stays within the screen
+	lines := strings.Split(model.View(), "\n")
+	assert.LessOrEqual(t, len(lines), 40)
+	for _, line := range lines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 120)
+	}
+
+	// Quiet hours darken the palette
+	bright := model.View()
+	now := time.Now()
+	config.Kiosk.QuietHours = domain.QuietHours{
+		Start:      now.Add(-time.Hour).Format("15:04"),
+		End:        now.Add(time.Hour).Format("15:04"),
+		Brightness: 0,
+	}
+	dimmed := model.View()
+	assert.NotEqual(t, bright, dimmed)
+	assert.Contains(t, dimmed, "38;2;0;0;0")
+}
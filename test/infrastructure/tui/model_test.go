@@ -2,6 +2,7 @@ package tui_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -144,3 +147,145 @@ func TestModel_WithBankruptcyDataSource(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "bankruptcy-datasource", activeDataSource.Name())
 }
+
+// pressKey sends a key press to the model
+func pressKey(model *tui.Model, key string) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == " " {
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+	}
+	model.Update(msg)
+}
+
+func TestModel_AnimationControls(t *testing.T) {
+	configManager := core.NewConfigManager()
+	registry := core.NewPluginRegistry(configManager)
+
+	rainbowAnimationPlugin := animation.NewRainbowAnimationPlugin()
+	assert.NoError(t, registry.RegisterAnimation(rainbowAnimationPlugin))
+	assert.NoError(t, registry.InitializePlugin(rainbowAnimationPlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	assert.False(t, model.SettingsChanged())
+
+	// p cycles forward through the patterns and P cycles back
+	pressKey(model, "p")
+	assert.Equal(t, domain.PatternGradient, configManager.GetAnimationConfig().Pattern)
+	pressKey(model, "P")
+	pressKey(model, "P")
+	assert.Equal(t, domain.PatternWave, configManager.GetAnimationConfig().Pattern)
+
+	// + speeds up and - slows down the animation
+	speed := configManager.GetAnimationConfig().Speed
+	pressKey(model, "+")
+	assert.Less(t, configManager.GetAnimationConfig().Speed, speed)
+	pressKey(model, "-")
+	pressKey(model, "-")
+	assert.Greater(t, configManager.GetAnimationConfig().Speed, speed)
+
+	// t switches to the next theme
+	pressKey(model, "t")
+	assert.Equal(t, "pastel", configManager.GetAnimationConfig().Theme)
+
+	assert.True(t, model.SettingsChanged())
+}
+
+func TestModel_HelpOverlay(t *testing.T) {
+	model, _ := setupBankruptcyTestModel(t)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Load cost data through the refresh key
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.NotNil(t, cmd)
+	model.Update(cmd())
+
+	// The hint bar is shown at startup
+	view := model.View()
+	assert.Contains(t, view, "? help")
+	assert.NotContains(t, view, "Key bindings")
+
+	// ? opens the overlay with bindings and current settings
+	pressKey(model, "?")
+	view = model.View()
+	assert.Contains(t, view, "Key bindings")
+	assert.Contains(t, view, "next pattern")
+	assert.Contains(t, view, "rainbow")
+	assert.Equal(t, 40, lipgloss.Height(view), "Overlay should not change the view height")
+
+	// Escape closes it again
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.NotContains(t, model.View(), "Key bindings")
+}
+
+func TestModel_DataSourceFallback(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "flaky-datasource"
+	config.Plugins.DataSources = []string{"flaky-datasource", "daily-datasource"}
+	registry := core.NewPluginRegistry(configManager)
+
+	primary := &flakyDataSource{err: errors.New("ccusage timed out")}
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.RegisterDataSource(primary))
+	assert.NoError(t, registry.RegisterDataSource(&dailyDataSource{}))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializeAll())
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The backup serves the data and the footer tells why
+	refresh(t, model)
+	assert.Contains(t, model.View(), "Data from daily-datasource · flaky-datasource: ccusage timed out")
+
+	status := model.Status()
+	assert.True(t, status.IsRunning)
+	assert.Equal(t, "daily-datasource", status.DataSource)
+	assert.Equal(t, "flaky-datasource: ccusage timed out", status.FallbackReason)
+	assert.Contains(t, status.ActivePlugins, "flaky-datasource")
+	assert.Equal(t, 0, status.ErrorCount)
+
+	// Once the first data source recovers the footer line goes away
+	primary.err = nil
+	refresh(t, model)
+	assert.NotContains(t, model.View(), "Data from")
+	assert.Equal(t, "flaky-datasource", model.Status().DataSource)
+	assert.Empty(t, model.Status().FallbackReason)
+}
+
+func TestModel_ErrorHint(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "flaky-datasource"
+	registry := core.NewPluginRegistry(configManager)
+
+	dataSource := &flakyDataSource{err: &domain.DataSourceError{
+		Class: domain.ErrNodeMissing,
+		Err:   errors.New(`failed to execute ccusage command: exec: "npx": executable file not found in $PATH`),
+	}}
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animation.NewRainbowAnimationPlugin()))
+	assert.NoError(t, registry.RegisterDisplay(display.NewRainbowTUIPlugin()))
+	assert.NoError(t, registry.InitializeAll())
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// A classified failure comes with its remedy
+	refresh(t, model)
+	view := model.View()
+	assert.Contains(t, view, `Error: failed to execute ccusage command: exec: "npx": executable file not found in $PATH`)
+	assert.Contains(t, view, "Hint: Install Node.js")
+	assert.Contains(t, view, "Press 'r' to retry")
+
+	dataSource.err = &domain.DataSourceError{Class: domain.ErrTimeout, Err: errors.New("failed to execute ccusage command: timed out after 30s")}
+	refresh(t, model)
+	assert.Contains(t, model.View(), "raise datasource.timeout")
+
+	// Other failures have none
+	dataSource.err = errors.New("disk on fire")
+	refresh(t, model)
+	assert.Contains(t, model.View(), "Error: disk on fire")
+	assert.NotContains(t, model.View(), "Hint:")
+}
@@ -0,0 +1,171 @@
+package tui_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+// dailyDataSource returns cost data with a daily series ending today
+type dailyDataSource struct{}
+
+func (d *dailyDataSource) Name() string        { return "daily-datasource" }
+func (d *dailyDataSource) Version() string     { return "1.0.0" }
+func (d *dailyDataSource) Description() string { return "Returns cost data with a daily series" }
+func (d *dailyDataSource) Initialize(config map[string]interface{}) error {
+	return nil
+}
+func (d *dailyDataSource) Shutdown() error        { return nil }
+func (d *dailyDataSource) IsEnabled() bool        { return true }
+func (d *dailyDataSource) SupportsRealtime() bool { return false }
+func (d *dailyDataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+func (d *dailyDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	today := time.Now()
+	return &domain.CostData{
+		TotalCost: 30.0,
+		Currency:  "USD",
+		Timestamp: today,
+		ModelBreakdown: map[string]float64{
+			"claude-opus-4":   20.0,
+			"claude-sonnet-4": 10.0,
+		},
+		Daily: []domain.DailyCost{
+			{Date: today.AddDate(0, 0, -1), Cost: 18.0, ModelBreakdown: map[string]float64{"claude-opus-4": 15.0, "claude-sonnet-4": 3.0}},
+			{Date: today, Cost: 12.0, ModelBreakdown: map[string]float64{"claude-opus-4": 5.0, "claude-sonnet-4": 7.0}},
+		},
+	}, nil
+}
+
+// findText returns the cell position of the first occurrence of text in the view
+func findText(view, text string) (int, int, bool) {
+	for y, line := range strings.Split(view, "\n") {
+		plain := ansi.Strip(line)
+		if i := strings.Index(plain, text); i >= 0 {
+			return ansi.StringWidth(plain[:i]), y, true
+		}
+	}
+	return 0, 0, false
+}
+
+func TestModel_MouseSupport(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "daily-datasource"
+	registry := core.NewPluginRegistry(configManager)
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.RegisterDataSource(&dailyDataSource{}))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	refresh(t, model)
+
+	view := model.View()
+	assert.Contains(t, view, "Breakdown · all")
+	assert.Contains(t, view, "Daily · 14d")
+
+	// Hovering a model row shows its exact cost
+	x, y, found := findText(view, "claude-opus-4")
+	assert.True(t, found)
+	model.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionMotion})
+	assert.Contains(t, model.View(), "claude-opus-4: $20.0000 · click to drill in")
+
+	// Clicking it drills into the model, and clicking again goes back out
+	model.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	assert.Contains(t, model.View(), "Breakdown · all · claude-opus-4")
+	model.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	assert.NotContains(t, model.View(), "Breakdown · all · claude-opus-4")
+
+	// Clicking the total cycles the report period
+	model.Update(tea.MouseMsg{X: 60, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	view = model.View()
+	assert.Contains(t, view, "Breakdown · month")
+	assert.Contains(t, view, "Total (month): $30.0000")
+	model.Update(tea.MouseMsg{X: 60, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	assert.Contains(t, model.View(), "Total (today): $12.0000")
+
+	// The wheel widens and narrows the sparkline window
+	model.Update(tea.MouseMsg{X: 60, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	assert.Contains(t, model.View(), "Daily · 21d")
+	model.Update(tea.MouseMsg{X: 60, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	model.Update(tea.MouseMsg{X: 60, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	assert.Contains(t, model.View(), "Daily · 7d")
+
+	// The breakdown panel can be hidden with its key
+	pressKey(model, "b")
+	assert.NotContains(t, model.View(), "Breakdown")
+}
+
+func TestModel_DashboardHidesPanel(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "daily-datasource"
+	configManager.GetConfig().Plugins.Display = "dashboard"
+	registry := core.NewPluginRegistry(configManager)
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewDashboardPlugin()
+	assert.NoError(t, registry.RegisterDataSource(&dailyDataSource{}))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	refresh(t, model)
+
+	// The dashboard shows its own breakdown instead of the details panel
+	view := model.View()
+	assert.Contains(t, view, "Models")
+	assert.NotContains(t, view, "Breakdown")
+}
+
+func TestModel_DashboardBudgetIgnoresPeriod(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "daily-datasource"
+	configManager.GetConfig().Plugins.Display = "dashboard"
+	configManager.GetConfig().Display.Dashboard.Budget = 100
+	registry := core.NewPluginRegistry(configManager)
+
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewDashboardPlugin()
+	assert.NoError(t, registry.RegisterDataSource(&dailyDataSource{}))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	refresh(t, model)
+
+	// Yesterday counts towards the month unless the month started today
+	spent := "$30.00 of $100.00"
+	if time.Now().Day() == 1 {
+		spent = "$12.00 of $100.00"
+	}
+	assert.Contains(t, model.View(), spent)
+
+	// Showing today only leaves the month-to-date budget as it was
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	view := model.View()
+	assert.Contains(t, view, "$7.00") // Today's cost of claude-sonnet-4
+	assert.Contains(t, view, spent)
+}
@@ -0,0 +1,63 @@
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Record(t *testing.T) {
+	configManager := core.NewConfigManager()
+	config := configManager.GetConfig()
+	config.Plugins.DataSource = "flaky-datasource"
+	config.Animation.Speed = 250 * time.Millisecond
+	registry := core.NewPluginRegistry(configManager)
+
+	dataSource := &flakyDataSource{}
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	// Frames follow the animation speed in virtual time
+	var offsets []time.Duration
+	var views []string
+	model := tui.NewModel(context.Background(), registry, configManager)
+	err := model.Record(time.Second, 100, 30, func(offset time.Duration, view string) error {
+		offsets = append(offsets, offset)
+		views = append(views, view)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond, time.Second}, offsets)
+
+	// Every frame fills the canvas without the key hints, and the animation moves
+	for _, view := range views {
+		lines := strings.Split(view, "\n")
+		assert.LessOrEqual(t, len(lines), 30)
+		for _, line := range lines {
+			assert.LessOrEqual(t, ansi.StringWidth(line), 100)
+		}
+		assert.Contains(t, view, "Breakdown · all")
+		assert.NotContains(t, view, "quit")
+	}
+	assert.NotEqual(t, views[0], views[1])
+
+	// Data errors stop the recording
+	dataSource.err = errors.New("ccusage not found")
+	model = tui.NewModel(context.Background(), registry, configManager)
+	err = model.Record(time.Second, 100, 30, func(time.Duration, string) error { return nil })
+	assert.EqualError(t, err, "ccusage not found")
+}
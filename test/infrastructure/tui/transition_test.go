@@ -0,0 +1,143 @@
+package tui_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/core"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/tui"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/animation"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceDataSource returns the given costs one after another
+type sequenceDataSource struct {
+	costs []float64
+	calls int
+}
+
+func (s *sequenceDataSource) Name() string        { return "sequence-datasource" }
+func (s *sequenceDataSource) Version() string     { return "1.0.0" }
+func (s *sequenceDataSource) Description() string { return "Returns a fixed sequence of costs" }
+func (s *sequenceDataSource) Initialize(config map[string]interface{}) error {
+	return nil
+}
+func (s *sequenceDataSource) Shutdown() error        { return nil }
+func (s *sequenceDataSource) IsEnabled() bool        { return true }
+func (s *sequenceDataSource) SupportsRealtime() bool { return false }
+func (s *sequenceDataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+func (s *sequenceDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	cost := s.costs[min(s.calls, len(s.costs)-1)]
+	s.calls++
+	return &domain.CostData{TotalCost: cost, Currency: "USD", Timestamp: time.Now()}, nil
+}
+
+// refresh fetches cost data through the refresh key
+func refresh(t *testing.T, model *tui.Model) {
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.NotNil(t, cmd)
+	model.Update(cmd())
+}
+
+func TestModel_CostTransition(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "sequence-datasource"
+	registry := core.NewPluginRegistry(configManager)
+
+	dataSource := &sequenceDataSource{costs: []float64{41.20, 43.87}}
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The first value appears without a transition
+	refresh(t, model)
+	assert.NotContains(t, model.View(), "+$")
+
+	// A rising cost shows the delta badge
+	refresh(t, model)
+	assert.Contains(t, model.View(), "+$2.67")
+
+	// Without a delta badge the footer stays empty
+	configManager.GetConfig().Display.Transition.DeltaBadge = false
+	dataSource.costs = []float64{50}
+	refresh(t, model)
+	assert.NotContains(t, model.View(), "+$")
+}
+
+// memoryHistory records history entries in memory
+type memoryHistory struct {
+	entries []domain.HistoryEntry
+}
+
+func (h *memoryHistory) Record(ctx context.Context, entry domain.HistoryEntry) error {
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func (h *memoryHistory) List(ctx context.Context) ([]domain.HistoryEntry, error) {
+	return h.entries, nil
+}
+
+// runCmd executes a command and any commands it batches
+func runCmd(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmd(c)
+		}
+	}
+}
+
+func TestModel_MilestoneCelebration(t *testing.T) {
+	configManager := core.NewConfigManager()
+	configManager.GetConfig().Plugins.DataSource = "sequence-datasource"
+	configManager.GetConfig().Milestones.Bell = true
+	registry := core.NewPluginRegistry(configManager)
+
+	dataSource := &sequenceDataSource{costs: []float64{95, 101.5}}
+	animationPlugin := animation.NewRainbowAnimationPlugin()
+	displayPlugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, registry.RegisterDataSource(dataSource))
+	assert.NoError(t, registry.RegisterAnimation(animationPlugin))
+	assert.NoError(t, registry.RegisterDisplay(displayPlugin))
+	assert.NoError(t, registry.InitializePlugin(animationPlugin))
+	assert.NoError(t, registry.InitializePlugin(displayPlugin))
+
+	history := &memoryHistory{}
+	model := tui.NewModel(context.Background(), registry, configManager)
+	model.SetHistoryStore(history)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	refresh(t, model)
+	assert.NotContains(t, model.View(), "milestone")
+
+	// Crossing $100 celebrates, rings the bell with the next frame and records the milestone
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	_, cmd = model.Update(cmd())
+	runCmd(cmd)
+
+	view := model.View()
+	assert.Contains(t, view, "$100.00 milestone!")
+	assert.True(t, strings.HasSuffix(view, "\a"))
+	assert.Equal(t, 1, strings.Count(view, "\a"))
+	assert.Len(t, history.entries, 1)
+	assert.Equal(t, domain.HistoryEventMilestone, history.entries[0].Type)
+	assert.Equal(t, 100.0, history.entries[0].Amount)
+	assert.Equal(t, 101.5, history.entries[0].Cost)
+}
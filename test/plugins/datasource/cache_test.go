@@ -0,0 +1,180 @@
+package datasource_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
+	"github.com/stretchr/testify/assert"
+)
+
+// gatedLoader loads increasing costs, each load waiting until it is released
+type gatedLoader struct {
+	loads   atomic.Int32
+	release chan struct{}
+	err     atomic.Pointer[error]
+}
+
+func newGatedLoader() *gatedLoader {
+	return &gatedLoader{release: make(chan struct{})}
+}
+
+func (g *gatedLoader) load(ctx context.Context) (*domain.CostData, error) {
+	n := g.loads.Add(1)
+	<-g.release
+	if err := g.err.Load(); err != nil {
+		return nil, *err
+	}
+	return &domain.CostData{TotalCost: float64(n)}, nil
+}
+
+func (g *gatedLoader) fail(err error) {
+	g.err.Store(&err)
+}
+
+func TestCostCache_CoalescesLoads(t *testing.T) {
+	loader := newGatedLoader()
+	cache := datasource.NewCostCache(time.Hour, loader.load)
+
+	var wg sync.WaitGroup
+	results := make([]*domain.CostData, 20)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := cache.Get(context.Background())
+			assert.NoError(t, err)
+			results[i] = data
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return loader.loads.Load() == 1 }, time.Second, time.Millisecond)
+	close(loader.release)
+	wg.Wait()
+
+	// Every caller shares the single load
+	assert.Equal(t, int32(1), loader.loads.Load())
+	for _, data := range results {
+		assert.Same(t, results[0], data)
+	}
+
+	// Fresh data is served without loading
+	data, err := cache.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, results[0], data)
+	assert.False(t, cache.Updated().IsZero())
+}
+
+func TestCostCache_StaleWhileRevalidate(t *testing.T) {
+	loader := newGatedLoader()
+	close(loader.release)
+	cache := datasource.NewCostCache(0, loader.load) // Data is stale right away
+
+	data, err := cache.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, data.TotalCost)
+
+	// Stale data is returned at once while it is refreshed in the background
+	data, err = cache.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, data.TotalCost)
+	assert.True(t, data.Stale)
+	assert.Eventually(t, func() bool {
+		data, _ := cache.Get(context.Background())
+		return data.TotalCost > 1
+	}, time.Second, time.Millisecond)
+
+	// After a failed refresh the next caller waits for a retry and sees its error
+	loader.fail(errors.New("ccusage timed out"))
+	assert.Eventually(t, func() bool {
+		_, err := cache.Get(context.Background())
+		return err != nil
+	}, time.Second, time.Millisecond)
+
+	// A caller that gives up does not wait for the load
+	blocked := newGatedLoader()
+	cache = datasource.NewCostCache(time.Hour, blocked.load)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cache.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	close(blocked.release)
+}
+
+func TestCostCache_Clear(t *testing.T) {
+	loader := newGatedLoader()
+	close(loader.release)
+	cache := datasource.NewCostCache(time.Hour, loader.load)
+
+	_, err := cache.Get(context.Background())
+	assert.NoError(t, err)
+
+	cache.Clear()
+	assert.True(t, cache.Updated().IsZero())
+	data, err := cache.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, data.TotalCost)
+}
+
+// TestCcusageCliPlugin_ConcurrentFetches is meant to run with the race detector
+func TestCcusageCliPlugin_ConcurrentFetches(t *testing.T) {
+	runner := &fakeRunner{stdout: dailyReport, delay: 50 * time.Millisecond}
+	plugin := datasource.NewCcusageCliPlugin()
+	plugin.SetCommandRunner(runner)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"cache_time": "1h"}))
+
+	// Startup and refresh key presses fetch at the same time
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := plugin.FetchCostData(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 5.0, data.TotalCost)
+			_, _ = plugin.GetLastUpdated(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// Overlapping fetches share one ccusage run
+	assert.Equal(t, 1, runner.calls())
+	assert.Equal(t, []string{"ccusage", "daily", "--json"}, runner.commands[0].Args)
+
+	// Shutting down drops the cache
+	assert.NoError(t, plugin.Shutdown())
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+	_, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runner.calls())
+}
+
+// TestCcusageCliPlugin_InitializeDuringFetch is meant to run with the race detector
+func TestCcusageCliPlugin_InitializeDuringFetch(t *testing.T) {
+	runner := &fakeRunner{stdout: dailyReport, delay: time.Millisecond}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{"cache_time": "0s"})
+
+	// Health checks may initialize the plugin again while the TUI fetches
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			_, _ = plugin.FetchCostData(context.Background())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			assert.NoError(t, plugin.Initialize(map[string]interface{}{"timeout": "5s", "cache_time": "0s"}))
+			_ = plugin.HealthCheck(context.Background())
+		}
+	}()
+	wg.Wait()
+	assert.True(t, plugin.IsEnabled())
+}
@@ -2,8 +2,15 @@ package datasource_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
 	"github.com/stretchr/testify/assert"
 )
@@ -159,3 +166,203 @@ func TestCcusageCliPlugin_Initialize_TypeConversion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, plugin.IsEnabled())
 }
+
+func TestCcusageCliPlugin_FetchCostData_DailySeries(t *testing.T) {
+	// Fake ccusage executable printing a daily report
+	script := filepath.Join(t.TempDir(), "ccusage")
+	output := `{
+  "daily": [
+    {"date": "2025-06-01", "totalCost": 3.5, "inputTokens": 100, "outputTokens": 20,
+     "modelBreakdowns": [{"modelName": "claude-opus-4", "cost": 2.5}, {"modelName": "claude-sonnet-4", "cost": 1.0}]},
+    {"date": "2025-06-02", "totalCost": 1.5, "inputTokens": 50, "outputTokens": 10,
+     "modelBreakdowns": [{"modelName": "claude-sonnet-4", "cost": 1.5}]}
+  ],
+  "totals": {"totalCost": 5.0, "inputTokens": 150, "outputTokens": 30}
+}`
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\ncat <<'JSON'\n"+output+"\nJSON\n"), 0o755))
+
+	plugin := datasource.NewCcusageCliPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"ccusage_path": script}))
+
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, data.TotalCost)
+	assert.Equal(t, 180, data.Tokens.Total())
+	assert.Equal(t, 2.5, data.ModelBreakdown["claude-opus-4"])
+	assert.Equal(t, 2.5, data.ModelBreakdown["claude-sonnet-4"])
+
+	assert.Len(t, data.Daily, 2)
+	assert.Equal(t, "2025-06-01", data.Daily[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 3.5, data.Daily[0].Cost)
+	assert.Equal(t, 120, data.Daily[0].Tokens.Total())
+	assert.Equal(t, 1.5, data.Daily[1].ModelBreakdown["claude-sonnet-4"])
+}
+
+func TestCcusageCliPlugin_HealthCheck(t *testing.T) {
+	plugin := datasource.NewCcusageCliPlugin()
+	assert.Error(t, plugin.HealthCheck(context.Background())) // Not enabled
+
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{
+		"ccusage_path": filepath.Join(t.TempDir(), "missing-ccusage"),
+	}))
+	err := plugin.HealthCheck(context.Background())
+	assert.ErrorContains(t, err, "ccusage command not available")
+	assert.ErrorIs(t, err, domain.ErrBinaryNotFound)
+
+	executable, err := os.Executable()
+	assert.NoError(t, err)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"ccusage_path": executable}))
+	assert.NoError(t, plugin.HealthCheck(context.Background()))
+}
+
+func TestCcusageCliPlugin_FetchCostData_Command(t *testing.T) {
+	runner := &fakeRunner{stdout: dailyReport}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{"config_dir": "/home/me/.claude-work"})
+
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, data.TotalCost)
+	assert.Equal(t, 180, data.Tokens.Total())
+
+	// The default ccusage runs through npx
+	command := runner.lastCommand()
+	assert.Equal(t, "npx", command.Name)
+	assert.Equal(t, []string{"ccusage", "daily", "--json"}, command.Args)
+	assert.Equal(t, []string{"CLAUDE_CONFIG_DIR=/home/me/.claude-work"}, command.Env)
+
+	runner = &fakeRunner{stdout: dailyReport}
+	plugin = newFakePlugin(t, runner, map[string]interface{}{"ccusage_path": "/opt/bin/ccusage"})
+	_, err = plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "/opt/bin/ccusage", runner.lastCommand().Name)
+	assert.Equal(t, []string{"daily", "--json"}, runner.lastCommand().Args)
+	assert.Empty(t, runner.lastCommand().Env)
+}
+
+func TestCcusageCliPlugin_FetchCostData_MalformedJSON(t *testing.T) {
+	plugin := newFakePlugin(t, &fakeRunner{stdout: `{"daily": [`}, map[string]interface{}{})
+
+	_, err := plugin.FetchCostData(context.Background())
+	assert.ErrorContains(t, err, "failed to parse ccusage JSON output")
+	assert.ErrorContains(t, err, `raw output: {"daily": [`)
+	assert.ErrorIs(t, err, domain.ErrParseFailure)
+}
+
+func TestCcusageCliPlugin_FetchCostData_Timeout(t *testing.T) {
+	runner := &fakeRunner{stdout: dailyReport, delay: time.Second}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{"timeout": "20ms"})
+
+	start := time.Now()
+	_, err := plugin.FetchCostData(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorContains(t, err, "timed out after 20ms")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, domain.ErrTimeout)
+}
+
+func TestCcusageCliPlugin_FetchCostData_ExitStatus(t *testing.T) {
+	runner := &fakeRunner{
+		stdout:   "partial output",
+		stderr:   "npm warn config production\n\nError: ENOENT: no such file or directory, scandir '/home/me/.claude/projects'\n    at readdir\n",
+		exitCode: 1,
+	}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{})
+
+	// The end of the error output is surfaced
+	_, err := plugin.FetchCostData(context.Background())
+	assert.EqualError(t, err, "failed to execute ccusage command: exit status 1: npm warn config production | "+
+		"Error: ENOENT: no such file or directory, scandir '/home/me/.claude/projects' | at readdir")
+	assert.ErrorIs(t, err, domain.ErrCommandFailed)
+
+	// The whole error output is kept
+	var sourceErr *domain.DataSourceError
+	assert.ErrorAs(t, err, &sourceErr)
+	assert.Equal(t, strings.TrimSpace(runner.stderr), sourceErr.Stderr)
+
+	runner.stderr = ""
+	runner.exitCode = 127
+	plugin = newFakePlugin(t, runner, map[string]interface{}{})
+	_, err = plugin.FetchCostData(context.Background())
+	assert.EqualError(t, err, "failed to execute ccusage command: exit status 127")
+	assert.ErrorIs(t, err, domain.ErrBinaryNotFound)
+}
+
+func TestCcusageCliPlugin_FetchCostData_RunError(t *testing.T) {
+	runner := &fakeRunner{err: &exec.Error{Name: "npx", Err: exec.ErrNotFound}}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{})
+
+	// Without npx there is no Node.js
+	_, err := plugin.FetchCostData(context.Background())
+	assert.EqualError(t, err, `failed to execute ccusage command: exec: "npx": executable file not found in $PATH`)
+	assert.ErrorIs(t, err, domain.ErrNodeMissing)
+
+	runner.err = &exec.Error{Name: "ccusage", Err: exec.ErrNotFound}
+	plugin = newFakePlugin(t, runner, map[string]interface{}{"ccusage_path": "ccusage-nightly"})
+	_, err = plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrBinaryNotFound)
+
+	runner.err = errors.New("fork/exec: resource temporarily unavailable")
+	plugin = newFakePlugin(t, runner, map[string]interface{}{})
+	_, err = plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrCommandFailed)
+}
+
+func TestCcusageCliPlugin_FetchCostData_ErrorClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		stderr   string
+		exitCode int
+		class    error
+	}{
+		{"node missing", "/usr/bin/env: 'node': No such file or directory", 127, domain.ErrNodeMissing},
+		{"binary not found", "npm error could not determine executable to run", 1, domain.ErrBinaryNotFound},
+		{"permission denied", "Error: EACCES: permission denied, open '/home/me/.claude/projects/a.jsonl'", 1, domain.ErrPermissionDenied},
+		{"no usage data", "Error: No valid Claude data directories found", 1, domain.ErrNoUsageData},
+		{"other", "TypeError: cannot read properties of undefined", 1, domain.ErrCommandFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := newFakePlugin(t, &fakeRunner{stderr: tt.stderr, exitCode: tt.exitCode}, map[string]interface{}{})
+
+			_, err := plugin.FetchCostData(context.Background())
+			assert.ErrorIs(t, err, tt.class)
+			assert.ErrorContains(t, err, tt.stderr)
+		})
+	}
+}
+
+func TestCcusageCliPlugin_FetchCostData_EmptyDaily(t *testing.T) {
+	runner := &fakeRunner{stdout: `{"daily": [], "totals": {"totalCost": 0, "inputTokens": 0, "outputTokens": 0}}`}
+	plugin := newFakePlugin(t, runner, map[string]interface{}{})
+
+	// No usage yet is a cost of zero
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, data.TotalCost)
+	assert.Empty(t, data.Daily)
+	assert.Empty(t, data.ModelBreakdown)
+	assert.WithinDuration(t, time.Now(), data.Timestamp, time.Minute)
+}
+
+func TestCcusageCliPlugin_FetchCostData_ErrorOutput(t *testing.T) {
+	// Fake ccusage executable failing with a message on stderr
+	script := filepath.Join(t.TempDir(), "ccusage")
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho 'No valid Claude data directories found' >&2\nexit 2\n"), 0o755))
+
+	plugin := datasource.NewCcusageCliPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"ccusage_path": script}))
+
+	_, err := plugin.FetchCostData(context.Background())
+	assert.EqualError(t, err, "failed to execute ccusage command: exit status 2: No valid Claude data directories found")
+	assert.ErrorIs(t, err, domain.ErrNoUsageData)
+
+	// A ccusage that cannot be run
+	assert.NoError(t, os.Chmod(script, 0o644))
+	_, err = plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"ccusage_path": script + "-missing"}))
+	_, err = plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrBinaryNotFound)
+}
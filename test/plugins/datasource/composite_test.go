@@ -0,0 +1,114 @@
+package datasource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedDataSource returns a fixed cost after a delay, or its error when set
+type fixedDataSource struct {
+	cost  float64
+	err   error
+	delay time.Duration
+}
+
+func (f *fixedDataSource) Name() string                                   { return "fixed" }
+func (f *fixedDataSource) Version() string                                { return "1.0.0" }
+func (f *fixedDataSource) Description() string                            { return "Returns a fixed cost" }
+func (f *fixedDataSource) Initialize(config map[string]interface{}) error { return nil }
+func (f *fixedDataSource) Shutdown() error                                { return nil }
+func (f *fixedDataSource) IsEnabled() bool                                { return true }
+func (f *fixedDataSource) SupportsRealtime() bool                         { return false }
+func (f *fixedDataSource) GetLastUpdated(ctx context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+func (f *fixedDataSource) FetchCostData(ctx context.Context) (*domain.CostData, error) {
+	time.Sleep(f.delay) // Ignores the context like a source stuck on I/O
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.CostData{TotalCost: f.cost, Currency: "USD"}, nil
+}
+
+func TestCompositePlugin_FetchCostData(t *testing.T) {
+	plugin := datasource.NewCompositePlugin([]datasource.CompositeMember{
+		{Label: "laptop", Source: &fixedDataSource{cost: 10, delay: 20 * time.Millisecond}},
+		{Label: "desktop", Source: &fixedDataSource{cost: 5, delay: 20 * time.Millisecond}},
+		{Label: "broken", Source: &fixedDataSource{err: errors.New("ccusage not found")}},
+		{Label: "stuck", Source: &fixedDataSource{cost: 100, delay: time.Second}},
+	}, 100*time.Millisecond)
+	assert.Equal(t, "composite", plugin.Name())
+
+	_, err := plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrPluginNotEnabled)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// Sources are fetched concurrently and slow ones are cut off at the timeout
+	start := time.Now()
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Equal(t, 15.0, data.TotalCost)
+	assert.Len(t, data.Contributors, 4)
+	assert.Equal(t, "laptop", data.Contributors[0].Label)
+	assert.Equal(t, 10.0, data.Contributors[0].Cost)
+	assert.Equal(t, "ccusage not found", data.Contributors[2].Error)
+	assert.Contains(t, data.Contributors[3].Error, "timed out")
+
+	updated, err := plugin.GetLastUpdated(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, updated.IsZero())
+}
+
+func TestCompositePlugin_AllSourcesFail(t *testing.T) {
+	plugin := datasource.NewCompositePlugin([]datasource.CompositeMember{
+		{Label: "laptop", Source: &fixedDataSource{err: errors.New("ccusage not found")}},
+	}, 0)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	_, err := plugin.FetchCostData(context.Background())
+	assert.ErrorContains(t, err, "no composite source has cost data")
+	assert.ErrorContains(t, err, "laptop: ccusage not found")
+
+	assert.Error(t, datasource.NewCompositePlugin(nil, 0).Initialize(map[string]interface{}{}))
+}
+
+func TestCompositePlugin_MemberTimeout(t *testing.T) {
+	runner := &fakeRunner{stdout: dailyReport, delay: time.Second}
+	ccusage := newFakePlugin(t, runner, map[string]interface{}{"timeout": "30s"})
+	plugin := datasource.NewCompositePlugin([]datasource.CompositeMember{
+		{Label: "laptop", Source: ccusage},
+		{Label: "desktop", Source: &fixedDataSource{cost: 5}},
+	}, 20*time.Millisecond)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// The composite timeout is reported, not the timeout of the ccusage run
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "timed out: context deadline exceeded", data.Contributors[0].Error)
+	assert.Equal(t, 5.0, data.TotalCost)
+}
+
+func TestCompositePlugin_Shutdown(t *testing.T) {
+	owned := newFakePlugin(t, &fakeRunner{stdout: dailyReport}, map[string]interface{}{})
+	shared := newFakePlugin(t, &fakeRunner{stdout: dailyReport}, map[string]interface{}{})
+	plugin := datasource.NewCompositePlugin([]datasource.CompositeMember{
+		{Label: "work", Source: owned, Owned: true},
+		{Label: "personal", Source: shared},
+	}, 0)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// Only the members created for the composite are shut down with it
+	assert.NoError(t, plugin.Shutdown())
+	assert.False(t, plugin.IsEnabled())
+	assert.False(t, owned.IsEnabled())
+	assert.True(t, shared.IsEnabled())
+}
@@ -0,0 +1,68 @@
+package datasource_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRunner stands in for ccusage, returning canned output and exit code after a delay
+type fakeRunner struct {
+	stdout   string
+	stderr   string
+	exitCode int
+	err      error // Failure to run the command at all
+	delay    time.Duration
+
+	mu       sync.Mutex
+	commands []datasource.Command
+}
+
+func (f *fakeRunner) Run(ctx context.Context, command datasource.Command) (datasource.CommandResult, error) {
+	f.mu.Lock()
+	f.commands = append(f.commands, command)
+	f.mu.Unlock()
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return datasource.CommandResult{}, ctx.Err()
+	}
+
+	if f.err != nil {
+		return datasource.CommandResult{}, f.err
+	}
+	return datasource.CommandResult{Stdout: []byte(f.stdout), Stderr: []byte(f.stderr), ExitCode: f.exitCode}, nil
+}
+
+// calls returns how many times the command was run
+func (f *fakeRunner) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.commands)
+}
+
+// lastCommand returns the command run most recently
+func (f *fakeRunner) lastCommand() datasource.Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.commands[len(f.commands)-1]
+}
+
+// dailyReport is ccusage output with a single day
+const dailyReport = `{
+  "daily": [{"date": "2025-06-01", "totalCost": 5.0, "inputTokens": 150, "outputTokens": 30}],
+  "totals": {"totalCost": 5.0, "inputTokens": 150, "outputTokens": 30}
+}`
+
+// newFakePlugin creates an enabled ccusage plugin running the fake runner
+func newFakePlugin(t *testing.T, runner *fakeRunner, config map[string]interface{}) *datasource.CcusageCliPlugin {
+	plugin := datasource.NewCcusageCliPlugin()
+	plugin.SetCommandRunner(runner)
+	assert.NoError(t, plugin.Initialize(config))
+	return plugin
+}
@@ -0,0 +1,57 @@
+package datasource_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/snapshot"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/datasource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedDirPlugin_FetchCostData(t *testing.T) {
+	dir := snapshot.NewDir(t.TempDir())
+	plugin := datasource.NewSharedDirPlugin(dir, time.Hour)
+	assert.Equal(t, "shared-dir", plugin.Name())
+
+	_, err := plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrPluginNotEnabled)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// An empty directory has no data
+	_, err = plugin.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrDataNotFound)
+
+	now := time.Now()
+	snapshots := []domain.TeamSnapshot{
+		{Member: "alice", WrittenAt: now.Add(-time.Minute), Cost: &domain.CostData{TotalCost: 30, Currency: "USD", ModelBreakdown: map[string]float64{"opus": 30}}},
+		{Member: "bob", WrittenAt: now.Add(-10 * time.Minute), Cost: &domain.CostData{TotalCost: 12.5, Currency: "USD", ModelBreakdown: map[string]float64{"sonnet": 12.5}}},
+		{Member: "carol", WrittenAt: now.Add(-2 * time.Hour), Cost: &domain.CostData{TotalCost: 100, Currency: "USD"}},
+	}
+	for _, s := range snapshots {
+		assert.NoError(t, dir.Write(s))
+	}
+
+	// Recent snapshots are added up per member and stale ones are ignored
+	data, err := plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42.5, data.TotalCost)
+	assert.Equal(t, map[string]float64{"opus": 30, "sonnet": 12.5}, data.ModelBreakdown)
+	assert.Equal(t, []domain.Contribution{
+		{Label: "alice", Cost: 30},
+		{Label: "bob", Cost: 12.5},
+	}, data.Contributors)
+
+	updated, err := plugin.GetLastUpdated(context.Background())
+	assert.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-time.Minute), updated, time.Second)
+
+	// Without a maximum age every snapshot counts
+	plugin = datasource.NewSharedDirPlugin(dir, 0)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+	data, err = plugin.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 142.5, data.TotalCost)
+}
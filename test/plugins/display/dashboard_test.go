@@ -0,0 +1,148 @@
+package display_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDashboardData(width, height int) *domain.DisplayData {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.Local)
+	return &domain.DisplayData{
+		Cost: &domain.CostData{
+			TotalCost: 123.45,
+			Currency:  "USD",
+			Tokens:    domain.TokenUsage{Input: 1234567, Output: 89012},
+			ModelBreakdown: map[string]float64{
+				"claude-opus-4":   86.40,
+				"claude-sonnet-4": 37.05,
+			},
+			Daily: []domain.DailyCost{
+				{Date: now.AddDate(0, 0, -1), Cost: 50},
+				{Date: now, Cost: 30},
+			},
+		},
+		Config: &domain.DisplayConfig{
+			Size:         domain.DisplaySize{Width: width, Height: height},
+			Format:       domain.DefaultNumberFormat(),
+			ColorProfile: domain.ColorProfileNone,
+			Dashboard:    domain.DashboardConfig{Budget: 200},
+		},
+		LastUpdated: now,
+		Now:         now,
+	}
+}
+
+func TestNewDashboardPlugin(t *testing.T) {
+	plugin := display.NewDashboardPlugin()
+	assert.Equal(t, "dashboard", plugin.Name())
+	assert.Equal(t, "1.0.0", plugin.Version())
+	assert.False(t, plugin.IsEnabled())
+	assert.True(t, plugin.GetCapabilities().ShowsBreakdown)
+
+	_, err := plugin.Render(context.Background(), newDashboardData(120, 36))
+	assert.Error(t, err)
+
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+	assert.True(t, plugin.IsEnabled())
+	assert.NoError(t, plugin.Shutdown())
+	assert.False(t, plugin.IsEnabled())
+}
+
+func TestDashboardPlugin_Render(t *testing.T) {
+	plugin := display.NewDashboardPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	output, err := plugin.Render(context.Background(), newDashboardData(120, 36))
+	assert.NoError(t, err)
+
+	// Every pane of the default layout fits and the output fills the screen exactly
+	for _, text := range []string{"Total", "Tokens", "Models", "Daily", "Budget", "claude-opus-4", "$86.40", "70.0%", "1,234,567", "max $50.00", "40%", "$80.00 of $200.00", "USD · 2 models"} {
+		assert.Contains(t, output, text)
+	}
+	lines := strings.Split(output, "\n")
+	assert.Len(t, lines, 36)
+	for _, line := range lines {
+		assert.Equal(t, 120, ansi.StringWidth(line))
+	}
+
+	// Without data the panes say so
+	data := newDashboardData(120, 36)
+	data.Cost = &domain.CostData{TotalCost: 1, Currency: "USD"}
+	data.Config.Dashboard.Budget = 0
+	output, err = plugin.Render(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "No model data")
+	assert.Contains(t, output, "No daily data")
+	assert.Contains(t, output, "No token data")
+	assert.Contains(t, output, "No budget set")
+}
+
+func TestDashboardPlugin_MonthBoundary(t *testing.T) {
+	plugin := display.NewDashboardPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// On the first of the month only today counts towards the budget
+	data := newDashboardData(120, 36)
+	data.Now = time.Date(2026, 11, 1, 9, 0, 0, 0, time.Local)
+	data.Cost.Daily = []domain.DailyCost{
+		{Date: time.Date(2026, 10, 31, 0, 0, 0, 0, time.Local), Cost: 50},
+		{Date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local), Cost: 30},
+	}
+
+	output, err := plugin.Render(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "15%")
+	assert.Contains(t, output, "$30.00 of $200.00")
+	assert.Contains(t, output, "11-01") // The daily chart ends on the current day
+}
+
+func TestDashboardPlugin_Layout(t *testing.T) {
+	plugin := display.NewDashboardPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	data := newDashboardData(100, 30)
+	data.Config.Dashboard.Layout = []domain.DashboardRow{
+		{Columns: []domain.DashboardColumn{{Pane: domain.PaneBudget, Weight: 1}, {Pane: domain.PaneModels, Weight: 3}}},
+	}
+	output, err := plugin.Render(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Budget")
+	assert.Contains(t, output, "Models")
+	assert.NotContains(t, output, "Tokens")
+
+	// Column weights split the width: the budget pane gets a quarter
+	firstLine := ansi.Strip(strings.Split(output, "\n")[0])
+	assert.Equal(t, 25, ansi.StringWidth(firstLine[:strings.Index(firstLine, "╭─ Models")]))
+}
+
+func TestDashboardPlugin_AdaptiveCollapse(t *testing.T) {
+	plugin := display.NewDashboardPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// Lower priority panes are dropped first on smaller terminals
+	output, err := plugin.Render(context.Background(), newDashboardData(80, 24))
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Models")
+	assert.Contains(t, output, "Budget")
+	assert.NotContains(t, output, "Tokens")
+	assert.NotContains(t, output, "Daily")
+	assert.Len(t, strings.Split(output, "\n"), 24)
+
+	// With room for the total only, it is drawn like the rainbow display
+	data := newDashboardData(18, 8)
+	output, err = plugin.Render(context.Background(), data)
+	assert.NoError(t, err)
+
+	rainbow := display.NewRainbowTUIPlugin()
+	assert.NoError(t, rainbow.Initialize(map[string]interface{}{}))
+	expected, err := rainbow.Render(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, output)
+}
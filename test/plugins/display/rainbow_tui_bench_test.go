@@ -0,0 +1,147 @@
+package display_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/infrastructure/terminal"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/stretchr/testify/assert"
+)
+
+// sgrPattern matches SGR escape sequences
+var sgrPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// newLargeFrameData builds display data for a large terminal frame
+func newLargeFrameData(profile domain.ColorProfile, colors []string) *domain.DisplayData {
+	return &domain.DisplayData{
+		Cost: &domain.CostData{
+			TotalCost: 1234.56,
+			Currency:  "USD",
+			Timestamp: time.Now(),
+		},
+		Animation: &domain.AnimationFrame{Colors: colors},
+		Config: &domain.DisplayConfig{
+			Size:         domain.DisplaySize{Width: 200, Height: 50},
+			Format:       domain.DefaultNumberFormat(),
+			ColorProfile: profile,
+		},
+	}
+}
+
+// renderPerRune reproduces the previous renderer, which styled every rune individually
+func renderPerRune(text string, colors []string, profile domain.ColorProfile) string {
+	renderer := terminal.NewRenderer(profile)
+
+	var styledText strings.Builder
+	lines := strings.Split(text, "\n")
+	for lineIndex, line := range lines {
+		for i, char := range line {
+			color := colors[(lineIndex*len(line)+i)%len(colors)]
+			style := renderer.NewStyle().Foreground(terminal.AdaptColor(color, profile))
+			styledText.WriteString(style.Render(string(char)))
+		}
+		if lineIndex < len(lines)-1 {
+			styledText.WriteString("\n")
+		}
+	}
+	return styledText.String()
+}
+
+// cellColors returns the active escape sequence for every non-space cell
+func cellColors(output string) []string {
+	var cells []string
+	active := ""
+	for len(output) > 0 {
+		if loc := sgrPattern.FindStringIndex(output); loc != nil && loc[0] == 0 {
+			sequence := output[:loc[1]]
+			if sequence == terminal.ResetSequence {
+				active = ""
+			} else {
+				active = sequence
+			}
+			output = output[loc[1]:]
+			continue
+		}
+
+		char, size := utf8.DecodeRuneInString(output)
+		if char != ' ' && char != '\n' {
+			cells = append(cells, active)
+		}
+		output = output[size:]
+	}
+	return cells
+}
+
+func TestRainbowTUIPlugin_Render_BatchesEscapeSequences(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	ctx := context.Background()
+
+	err := plugin.Initialize(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	palettes := map[string][]string{
+		"rainbow": {"#FF0000", "#FF8000", "#FFFF00", "#80FF00", "#00FF00", "#00FF80", "#00FFFF", "#0080FF", "#0000FF"},
+		"pulse":   {"#FF00FF"},
+	}
+
+	for name, colors := range palettes {
+		for _, profile := range []domain.ColorProfile{domain.ColorProfileTrueColor, domain.ColorProfileANSI256, domain.ColorProfileANSI} {
+			t.Run(name+"/"+string(profile), func(t *testing.T) {
+				data := newLargeFrameData(profile, colors)
+				batched, err := plugin.Render(ctx, data)
+				assert.NoError(t, err)
+
+				// The uncolored frame is the reference text for the per-rune renderer
+				data.Animation = nil
+				plain, err := plugin.Render(ctx, data)
+				assert.NoError(t, err)
+				perRune := renderPerRune(plain, colors, profile)
+
+				// Same text and the same color in every visible cell
+				assert.Equal(t, plain, sgrPattern.ReplaceAllString(batched, ""))
+				assert.Equal(t, cellColors(perRune), cellColors(batched))
+
+				// Far fewer bytes on the wire
+				assert.Less(t, len(batched), len(perRune)/2,
+					"batched frame is %d bytes, per-rune frame is %d bytes", len(batched), len(perRune))
+			})
+		}
+	}
+}
+
+func BenchmarkRainbowTUIPlugin_Render(b *testing.B) {
+	plugin := display.NewRainbowTUIPlugin()
+	_ = plugin.Initialize(map[string]interface{}{})
+	ctx := context.Background()
+	data := newLargeFrameData(domain.ColorProfileTrueColor, []string{"#FF0000", "#FF8000", "#FFFF00", "#80FF00", "#00FF00", "#00FFFF"})
+
+	var output string
+	b.ReportAllocs()
+	for b.Loop() {
+		output, _ = plugin.Render(ctx, data)
+	}
+	b.ReportMetric(float64(len(output)), "bytes/frame")
+}
+
+func BenchmarkRainbowTUIPlugin_Render_PerRuneBaseline(b *testing.B) {
+	plugin := display.NewRainbowTUIPlugin()
+	_ = plugin.Initialize(map[string]interface{}{})
+	ctx := context.Background()
+	colors := []string{"#FF0000", "#FF8000", "#FFFF00", "#80FF00", "#00FF00", "#00FFFF"}
+	data := newLargeFrameData(domain.ColorProfileTrueColor, colors)
+	data.Animation = nil
+	plain, _ := plugin.Render(ctx, data)
+
+	var output string
+	b.ReportAllocs()
+	for b.Loop() {
+		output = renderPerRune(plain, colors, domain.ColorProfileTrueColor)
+	}
+	b.ReportMetric(float64(len(output)), "bytes/frame")
+}
@@ -2,11 +2,13 @@ package display_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
 	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -283,3 +285,241 @@ func TestRainbowTUIPlugin_Render_LargeDisplay(t *testing.T) {
 	// Large display output should be longer than small display output
 	assert.True(t, len(output) > 100, "Large display should generate substantial output")
 }
+
+func TestRainbowTUIPlugin_Render_Currencies(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	ctx := context.Background()
+
+	// Initialize plugin
+	err := plugin.Initialize(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	abbreviated := domain.DefaultNumberFormat()
+	abbreviated.Abbreviate = true
+
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		format   domain.NumberFormat
+	}{
+		{"USD with thousands separator", 1234.56, "USD", domain.DefaultNumberFormat()},
+		{"EUR", 42.5, "EUR", domain.DefaultNumberFormat()},
+		{"JPY", 6543, "JPY", domain.DefaultNumberFormat()},
+		{"GBP", 12.34, "GBP", domain.DefaultNumberFormat()},
+		{"Currency code", 12.34, "CHF", domain.DefaultNumberFormat()},
+		{"Negative", -5, "USD", domain.DefaultNumberFormat()},
+		{"Abbreviated thousands", 4321, "USD", abbreviated},
+		{"Abbreviated millions", 1200000, "USD", abbreviated},
+	}
+
+	for _, tt := range tests {
+		for _, size := range []domain.DisplaySize{{Width: 30, Height: 8}, {Width: 200, Height: 40}} {
+			displayData := &domain.DisplayData{
+				Cost: &domain.CostData{
+					TotalCost: tt.amount,
+					Currency:  tt.currency,
+					Timestamp: time.Now(),
+				},
+				Config: &domain.DisplayConfig{
+					Size:   size,
+					Format: tt.format,
+				},
+			}
+
+			output, err := plugin.Render(ctx, displayData)
+			assert.NoError(t, err, tt.name)
+			assert.Contains(t, output, "█", tt.name)
+
+			// Glyph rows share a width within each font, so all art lines stay aligned
+			lineWidths := map[int]bool{}
+			for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+				if strings.TrimSpace(line) != "" {
+					lineWidths[len([]rune(line))] = true
+				}
+			}
+			assert.Len(t, lineWidths, 1, tt.name)
+		}
+	}
+}
+
+func TestRainbowTUIPlugin_Render_EveryCharacter(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	// Currencies without a symbol show their code, which must be drawn like the digits
+	for _, cost := range []*domain.CostData{{TotalCost: 12.34, Currency: "CHF"}, {TotalCost: -5, Currency: "USD"}} {
+		for _, size := range []domain.DisplaySize{{Width: 40, Height: 8}, {Width: 200, Height: 40}} {
+			costText := domain.FormatCost(cost.TotalCost, cost.Currency, domain.DefaultNumberFormat())
+			for i, char := range []rune(costText) {
+				if char == ' ' {
+					continue
+				}
+
+				// Highlighting one character shows whether it has a glyph
+				highlight := make([]bool, len([]rune(costText)))
+				highlight[i] = true
+				grid, err := plugin.RenderGrid(context.Background(), &domain.DisplayData{
+					Cost:      cost,
+					Animation: &domain.AnimationFrame{Colors: []string{"#FF0000"}},
+					Config:    &domain.DisplayConfig{Size: size, Format: domain.DefaultNumberFormat()},
+					Highlight: highlight,
+				})
+				assert.NoError(t, err)
+
+				highlighted := 0
+				for _, row := range grid.Rows {
+					for _, cell := range row {
+						if cell.Color == "#FFFFFF" {
+							highlighted++
+						}
+					}
+				}
+				assert.Greater(t, highlighted, 0, "%q in %q", char, costText)
+			}
+		}
+	}
+}
+
+func TestRainbowTUIPlugin_Render_ColorProfiles(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	ctx := context.Background()
+
+	// Initialize plugin
+	err := plugin.Initialize(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		profile     domain.ColorProfile
+		contains    string
+		notContains string
+	}{
+		{domain.ColorProfileTrueColor, "38;2;", ""},
+		{domain.ColorProfileANSI256, "38;5;", "38;2;"},
+		{domain.ColorProfileANSI, "\x1b[", "38;5;"},
+		{domain.ColorProfileNone, "█", "\x1b["},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			displayData := &domain.DisplayData{
+				Cost: &domain.CostData{
+					TotalCost: 25.75,
+					Currency:  "USD",
+					Timestamp: time.Now(),
+				},
+				Animation: &domain.AnimationFrame{
+					Colors: []string{"#FF0000", "#FF8000", "#FFFF00", "#00FF00", "#0000FF", "#8000FF"},
+				},
+				Config: &domain.DisplayConfig{
+					Size:         domain.DisplaySize{Width: 80, Height: 24},
+					Format:       domain.DefaultNumberFormat(),
+					ColorProfile: tt.profile,
+				},
+			}
+
+			output, err := plugin.Render(ctx, displayData)
+			assert.NoError(t, err)
+			assert.Contains(t, output, tt.contains)
+			if tt.notContains != "" {
+				assert.NotContains(t, output, tt.notContains)
+			}
+		})
+	}
+}
+
+func TestRainbowTUIPlugin_Render_Highlight(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	ctx := context.Background()
+
+	// Initialize plugin
+	err := plugin.Initialize(map[string]interface{}{})
+	assert.NoError(t, err)
+
+	newDisplayData := func(highlight []bool) *domain.DisplayData {
+		return &domain.DisplayData{
+			Cost: &domain.CostData{
+				TotalCost: 25.75,
+				Currency:  "USD",
+				Timestamp: time.Now(),
+			},
+			Animation: &domain.AnimationFrame{
+				Colors: []string{"#FF0000", "#00FF00", "#0000FF"},
+			},
+			Config: &domain.DisplayConfig{
+				Size:         domain.DisplaySize{Width: 80, Height: 24},
+				Format:       domain.DefaultNumberFormat(),
+				ColorProfile: domain.ColorProfileTrueColor,
+			},
+			Highlight: highlight,
+		}
+	}
+	highlightSequence := "38;2;255;255;255"
+
+	plain, err := plugin.Render(ctx, newDisplayData(nil))
+	assert.NoError(t, err)
+	assert.NotContains(t, plain, highlightSequence)
+
+	// Highlight the last digit of "$25.75"
+	highlighted, err := plugin.Render(ctx, newDisplayData([]bool{false, false, false, false, false, true}))
+	assert.NoError(t, err)
+	assert.Contains(t, highlighted, highlightSequence)
+	assert.Equal(t, ansi.Strip(plain), ansi.Strip(highlighted), "Highlighting should only change colors")
+
+	// Highlighted cells sit at the right end of each line
+	for _, line := range strings.Split(highlighted, "\n") {
+		index := strings.Index(line, highlightSequence)
+		if index < 0 {
+			continue
+		}
+		for _, rainbowSequence := range []string{"38;2;255;0;0", "38;2;0;255;0", "38;2;0;0;255"} {
+			assert.NotContains(t, line[index:], rainbowSequence, "No rainbow cells should follow the last digit")
+		}
+	}
+}
+
+func TestRainbowTUIPlugin_RenderGrid(t *testing.T) {
+	plugin := display.NewRainbowTUIPlugin()
+	ctx := context.Background()
+
+	palette := []string{"#FF0000", "#00FF00", "#0000FF"}
+	displayData := &domain.DisplayData{
+		Cost:      &domain.CostData{TotalCost: 25.75, Currency: "USD"},
+		Animation: &domain.AnimationFrame{Colors: palette},
+		Config:    &domain.DisplayConfig{Size: domain.DisplaySize{Width: 80, Height: 24}},
+		Highlight: []bool{false, true},
+	}
+
+	_, err := plugin.RenderGrid(ctx, displayData)
+	assert.EqualError(t, err, "plugin is not enabled")
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	grid, err := plugin.RenderGrid(ctx, displayData)
+	assert.NoError(t, err)
+	assert.Equal(t, 24, grid.Height)
+	assert.Len(t, grid.Rows, grid.Height)
+
+	// The grid holds the same art as the terminal output
+	output, err := plugin.Render(ctx, displayData)
+	assert.NoError(t, err)
+	plainLines := strings.Split(ansi.Strip(output), "\n")
+	highlighted := 0
+	for y, row := range grid.Rows {
+		var line strings.Builder
+		for _, cell := range row {
+			line.WriteRune(cell.Char)
+			switch {
+			case cell.Char == ' ':
+				assert.Empty(t, cell.Color)
+			case cell.Color == "#FFFFFF":
+				highlighted++
+			default:
+				assert.Contains(t, palette, cell.Color)
+			}
+		}
+		assert.Equal(t, plainLines[y], line.String())
+	}
+
+	// The changed digit is drawn in the highlight color
+	assert.Greater(t, highlighted, 0)
+}
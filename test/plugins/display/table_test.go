@@ -0,0 +1,49 @@
+package display_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/display"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTablePlugin_Render(t *testing.T) {
+	plugin := display.NewTablePlugin()
+	assert.Equal(t, "table", plugin.Name())
+	assert.True(t, plugin.GetCapabilities().ShowsBreakdown)
+
+	_, err := plugin.Render(context.Background(), newDashboardData(80, 24))
+	assert.Error(t, err)
+
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+	output, err := plugin.Render(context.Background(), newDashboardData(80, 24))
+	assert.NoError(t, err)
+
+	for _, text := range []string{"Model", "Share", "claude-opus-4", "$86.40", "70.0%", "Total", "$123.45", "Tokens: 1,234,567 in · 89,012 out"} {
+		assert.Contains(t, output, text)
+	}
+	assert.Less(t, strings.Index(output, "claude-opus-4"), strings.Index(output, "claude-sonnet-4"))
+	assert.Len(t, strings.Split(output, "\n"), 24)
+}
+
+func TestTablePlugin_RenderContributors(t *testing.T) {
+	plugin := display.NewTablePlugin()
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	data := newDashboardData(80, 30)
+	data.Cost.Contributors = []domain.Contribution{
+		{Label: "laptop", Cost: 100},
+		{Label: "desktop", Cost: 23.45},
+		{Label: "work", Error: "timed out"},
+	}
+	output, err := plugin.Render(context.Background(), data)
+	assert.NoError(t, err)
+
+	for _, text := range []string{"Source", "laptop", "$100.00", "81.0%", "desktop", "work", "unavailable"} {
+		assert.Contains(t, output, text)
+	}
+	assert.NotContains(t, output, "timed out")
+}
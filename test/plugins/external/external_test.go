@@ -0,0 +1,416 @@
+package external_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/ccusage-gorgeous/internal/application/interfaces"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/domain"
+	"github.com/airRnot1106/ccusage-gorgeous/internal/plugins/external"
+	"github.com/stretchr/testify/assert"
+)
+
+// helperSpec declares the test binary itself as an external plugin running in the given mode
+func helperSpec(mode string, args ...string) domain.ExternalPluginConfig {
+	return domain.ExternalPluginConfig{
+		Command: os.Args[0],
+		Args:    append([]string{"-test.run=^TestHelperPlugin$", "--", mode}, args...),
+		Timeout: 2 * time.Second,
+	}
+}
+
+// loadHelper loads the helper plugin and shuts it down when the test ends
+func loadHelper(t *testing.T, spec domain.ExternalPluginConfig) interfaces.Plugin {
+	t.Helper()
+	plugin, err := external.Load(context.Background(), spec)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { _ = plugin.Shutdown() })
+	return plugin
+}
+
+func TestLoad_DataSource(t *testing.T) {
+	plugin := loadHelper(t, helperSpec("datasource"))
+
+	dataSource, ok := plugin.(interfaces.DataSourcePlugin)
+	assert.True(t, ok)
+	assert.Equal(t, "helper-datasource", plugin.Name())
+	assert.Equal(t, "0.1.0", plugin.Version())
+	assert.True(t, dataSource.SupportsRealtime())
+	assert.False(t, plugin.IsEnabled())
+	assert.Equal(t, []domain.ConfigOption{{Name: "cost", Type: "number", Default: float64(0)}},
+		plugin.(interfaces.ConfigurablePlugin).ConfigSchema())
+
+	_, err := dataSource.FetchCostData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrPluginNotEnabled)
+
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"cost": 12.5}))
+	assert.True(t, plugin.IsEnabled())
+
+	data, err := dataSource.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, data.TotalCost)
+	assert.Equal(t, "USD", data.Currency)
+
+	updated, err := dataSource.GetLastUpdated(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2025, updated.Year())
+
+	assert.NoError(t, plugin.Shutdown())
+	assert.False(t, plugin.IsEnabled())
+}
+
+func TestLoad_ConfiguredSettings(t *testing.T) {
+	spec := helperSpec("datasource")
+	spec.Name = "team-costs"
+	spec.Config = map[string]interface{}{"cost": 3.0}
+	plugin := loadHelper(t, spec)
+
+	assert.Equal(t, "team-costs", plugin.Name())
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{}))
+
+	data, err := plugin.(interfaces.DataSourcePlugin).FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, data.TotalCost)
+}
+
+func TestLoad_Display(t *testing.T) {
+	plugin := loadHelper(t, helperSpec("display"))
+
+	display, ok := plugin.(interfaces.DisplayPlugin)
+	assert.True(t, ok)
+	assert.Equal(t, 80, display.GetCapabilities().MaxWidth)
+	assert.NoError(t, plugin.Initialize(nil))
+
+	output, err := display.Render(context.Background(), &domain.DisplayData{Cost: &domain.CostData{TotalCost: 7}})
+	assert.NoError(t, err)
+	assert.Equal(t, "cost 7.00", output)
+
+	assert.NoError(t, display.ValidateDisplayConfig(&domain.DisplayConfig{Size: domain.DisplaySize{Width: 80}}))
+	err = display.ValidateDisplayConfig(&domain.DisplayConfig{Size: domain.DisplaySize{Width: 200}})
+	assert.ErrorContains(t, err, "too wide")
+}
+
+func TestLoad_Animation(t *testing.T) {
+	plugin := loadHelper(t, helperSpec("animation"))
+
+	animation, ok := plugin.(interfaces.AnimationPlugin)
+	assert.True(t, ok)
+	assert.Equal(t, []domain.AnimationPattern{domain.PatternPulse}, animation.GetSupportedPatterns())
+	assert.NoError(t, plugin.Initialize(nil))
+
+	frame, err := animation.GenerateFrame(context.Background(), "$1", 3, &domain.AnimationConfig{Colors: []string{"#FF0000"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "$1", frame.Text)
+	assert.Equal(t, []string{"#FF0000", "#FF0000", "#FF0000"}, frame.Colors)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("Unsupported protocol version", func(t *testing.T) {
+		_, err := external.Load(context.Background(), helperSpec("badversion"))
+		assert.ErrorContains(t, err, "unsupported protocol version 99")
+	})
+
+	t.Run("Unexpected kind", func(t *testing.T) {
+		spec := helperSpec("datasource")
+		spec.Kind = domain.PluginKindDisplay
+		_, err := external.Load(context.Background(), spec)
+		assert.ErrorContains(t, err, "is a datasource plugin, expected display")
+	})
+
+	t.Run("Missing executable", func(t *testing.T) {
+		_, err := external.Load(context.Background(), domain.ExternalPluginConfig{Command: filepath.Join(t.TempDir(), "missing")})
+		assert.ErrorContains(t, err, "failed to start plugin")
+	})
+}
+
+func TestDataSource_Timeout(t *testing.T) {
+	spec := helperSpec("slow")
+	spec.Timeout = 200 * time.Millisecond
+	plugin := loadHelper(t, spec)
+	assert.NoError(t, plugin.Initialize(nil))
+
+	start := time.Now()
+	_, err := plugin.(interfaces.DataSourcePlugin).FetchCostData(context.Background())
+	assert.ErrorContains(t, err, "did not answer fetch_cost_data within 200ms")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDisplay_TimeoutWhenNotReading(t *testing.T) {
+	spec := helperSpec("deaf")
+	spec.Timeout = 200 * time.Millisecond
+	plugin := loadHelper(t, spec)
+	assert.NoError(t, plugin.Initialize(nil))
+
+	// A request larger than the pipe buffer blocks the write until the plugin is killed
+	data := &domain.DisplayData{Cost: &domain.CostData{Currency: strings.Repeat("x", 1<<20)}}
+	start := time.Now()
+	_, err := plugin.(interfaces.DisplayPlugin).Render(context.Background(), data)
+	assert.ErrorContains(t, err, "did not answer render within 200ms")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDataSource_RestartsAfterCrash(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "crashed")
+	plugin := loadHelper(t, helperSpec("crash", marker))
+	dataSource := plugin.(interfaces.DataSourcePlugin)
+	assert.NoError(t, plugin.Initialize(map[string]interface{}{"cost": 4.0}))
+
+	// The first process dies while fetching
+	_, err := dataSource.FetchCostData(context.Background())
+	assert.ErrorContains(t, err, "plugin process exited")
+	assert.ErrorContains(t, err, "crashing on purpose")
+
+	// The next call starts a new process and initializes it again
+	data, err := dataSource.FetchCostData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, data.TotalCost)
+}
+
+func TestPlugin_HealthCheck(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "crashed")
+	plugin := loadHelper(t, helperSpec("crash", marker))
+	assert.NoError(t, plugin.Initialize(nil))
+
+	// Plugins without a health method are healthy while they answer
+	checker := plugin.(interfaces.HealthChecker)
+	assert.NoError(t, checker.HealthCheck(context.Background()))
+
+	// A crashed plugin is started again by the next health check
+	_, err := plugin.(interfaces.DataSourcePlugin).FetchCostData(context.Background())
+	assert.Error(t, err)
+	assert.NoError(t, checker.HealthCheck(context.Background()))
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestHelperPlugin$' -- datasource\n", os.Args[0])
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "team-costs"), []byte(script), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte(script), 0o755))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o755))
+
+	specs, err := external.Discover(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ExternalPluginConfig{{Command: filepath.Join(dir, "team-costs")}}, specs)
+
+	plugin := loadHelper(t, specs[0])
+	assert.Equal(t, "helper-datasource", plugin.Name())
+
+	specs, err = external.Discover(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, specs)
+}
+
+func TestDiscover_Manifest(t *testing.T) {
+	dir := t.TempDir()
+	pluginDir := filepath.Join(dir, "team")
+	assert.NoError(t, os.Mkdir(pluginDir, 0o755))
+	manifest := fmt.Sprintf(`
+name: team-costs
+kind: datasource
+version: 1.2.0
+description: Costs of the whole team
+command: %q
+args: ["-test.run=^TestHelperPlugin$", "--", "datasource"]
+timeout: 5s
+config_schema:
+  - name: endpoint
+    type: string
+    description: URL of the cost API
+    required: true
+`, os.Args[0])
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginDir, domain.PluginManifestFile), []byte(manifest), 0o644))
+
+	// A broken manifest is reported without hiding the valid plugins
+	brokenDir := filepath.Join(dir, "broken")
+	assert.NoError(t, os.Mkdir(brokenDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(brokenDir, domain.PluginManifestFile), []byte("name: broken\nkind: datasource\n"), 0o644))
+
+	specs, err := external.Discover(dir)
+	assert.ErrorContains(t, err, "no command")
+	assert.Len(t, specs, 1)
+
+	spec := specs[0]
+	assert.Equal(t, "team-costs", spec.Name)
+	assert.Equal(t, domain.PluginKindDataSource, spec.Kind)
+	assert.Equal(t, 5*time.Second, spec.Timeout)
+	assert.Equal(t, "1.2.0", spec.Manifest.Version)
+	schema := []domain.ConfigOption{{Name: "endpoint", Type: "string", Description: "URL of the cost API", Required: true}}
+	assert.Equal(t, schema, spec.ConfigSchema)
+
+	// The manifest name and schema take precedence over the handshake
+	plugin := loadHelper(t, spec)
+	assert.Equal(t, "team-costs", plugin.Name())
+	assert.Equal(t, schema, plugin.(interfaces.ConfigurablePlugin).ConfigSchema())
+}
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"No name", "kind: display\ncommand: ./run\n", "no name"},
+		{"Invalid kind", "name: a\nkind: theme\ncommand: ./run\n", "invalid kind: theme"},
+		{"No command", "name: a\nkind: display\n", "no command"},
+		{"Negative timeout", "name: a\nkind: display\ncommand: ./run\ntimeout: -1s\n", "timeout must not be negative"},
+		{"Not YAML", "name: [", "failed to parse plugin manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), domain.PluginManifestFile)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+			_, err := external.LoadManifest(path)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+
+	t.Run("Relative command", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "team", domain.PluginManifestFile)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte("name: a\nkind: display\ncommand: ./run.sh\n"), 0o644))
+
+		specs, err := external.Discover(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "team", "run.sh"), specs[0].Command)
+	})
+}
+
+// TestHelperPlugin is not a real test. It runs as an external plugin when the
+// test binary is started with a mode after "--".
+func TestHelperPlugin(t *testing.T) {
+	args := flag.Args()
+	if len(args) == 0 {
+		return
+	}
+	runHelperPlugin(args[0], args[1:])
+	os.Exit(0)
+}
+
+// runHelperPlugin answers protocol requests on stdin until it is closed
+func runHelperPlugin(mode string, args []string) {
+	kind := domain.PluginKindDataSource
+	switch mode {
+	case "display", "deaf":
+		kind = domain.PluginKindDisplay
+	case "animation":
+		kind = domain.PluginKindAnimation
+	}
+
+	var cost float64
+	initialized := false
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+
+		var result interface{}
+		var rpcErr *external.RPCError
+		switch req.Method {
+		case "handshake":
+			version := 1
+			if mode == "badversion" {
+				version = 99
+			}
+			result = map[string]interface{}{
+				"protocol_version": version,
+				"name":             "helper-" + string(kind),
+				"version":          "0.1.0",
+				"description":      "Test helper plugin",
+				"kind":             kind,
+				"realtime":         true,
+				"patterns":         []string{"pulse"},
+				"capabilities":     map[string]interface{}{"max_width": 80, "max_height": 24},
+				"config_schema":    []map[string]interface{}{{"name": "cost", "type": "number", "default": 0}},
+			}
+		case "initialize":
+			var params struct {
+				Config map[string]interface{} `json:"config"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			cost, _ = params.Config["cost"].(float64)
+			initialized = true
+		case "shutdown":
+		case "fetch_cost_data":
+			switch {
+			case mode == "slow":
+				continue // Never answer
+			case mode == "crash" && !fileExists(args[0]):
+				_ = os.WriteFile(args[0], nil, 0o644)
+				fmt.Fprintln(os.Stderr, "crashing on purpose")
+				os.Exit(1)
+			case !initialized:
+				rpcErr = &external.RPCError{Code: 1, Message: "not initialized"}
+			default:
+				result = domain.CostData{TotalCost: cost, Currency: "USD"}
+			}
+		case "last_updated":
+			result = map[string]string{"time": "2025-06-01T12:00:00Z"}
+		case "render":
+			var params struct {
+				Data domain.DisplayData `json:"data"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			result = map[string]string{"output": fmt.Sprintf("cost %.2f", params.Data.Cost.TotalCost)}
+		case "validate_display_config":
+			var params struct {
+				Config domain.DisplayConfig `json:"config"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			if params.Config.Size.Width > 80 {
+				rpcErr = &external.RPCError{Code: 2, Message: "too wide"}
+			}
+		case "generate_frame":
+			var params struct {
+				Text   string                 `json:"text"`
+				Config domain.AnimationConfig `json:"config"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			colors := make([]string, 0, 3)
+			for range 3 {
+				colors = append(colors, params.Config.Colors[0])
+			}
+			result = domain.AnimationFrame{Text: params.Text, Colors: colors}
+		default:
+			rpcErr = &external.RPCError{Code: -32601, Message: "method not found"}
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != nil {
+			response["error"] = rpcErr
+		} else {
+			response["result"] = result
+		}
+		_ = encoder.Encode(response)
+
+		if mode == "deaf" && initialized {
+			select {} // Stop reading requests
+		}
+	}
+}
+
+// fileExists reports whether the file exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}